@@ -230,7 +230,9 @@ func (in *Task) DeepCopyInto(out *Task) {
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		*out = make([]apis.ParameterDefinition, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.VolumeTemplate != nil {
 		in, out := &in.VolumeTemplate, &out.VolumeTemplate
@@ -46,6 +46,11 @@ type KubeStashConfig struct {
 
 	// NetworkPolicy specifies whether the networkPolicy is enabled or not
 	NetworkPolicy bool `json:"networkPolicy,omitempty"`
+
+	// Concurrency specifies the limits on the number of BackupSessions that can run at the same time.
+	// The BackupSessions exceeding the limits wait in the "Queued" phase until a running one completes.
+	// +optional
+	Concurrency *ConcurrencyLimits `json:"concurrency,omitempty"`
 }
 
 type LicenseOptions struct {
@@ -88,6 +93,23 @@ type NetVolAccessor struct {
 	PrivilegedMode bool `json:"privilegedMode,omitempty"`
 }
 
+type ConcurrencyLimits struct {
+	// MaxSessionsPerBackupStorage specifies the maximum number of BackupSessions that can run at the same time
+	// against a single BackupStorage. If not specified, there is no limit.
+	// +optional
+	MaxSessionsPerBackupStorage *int32 `json:"maxSessionsPerBackupStorage,omitempty"`
+
+	// MaxSessionsPerNamespace specifies the maximum number of BackupSessions that can run at the same time
+	// in a single namespace. If not specified, there is no limit.
+	// +optional
+	MaxSessionsPerNamespace *int32 `json:"maxSessionsPerNamespace,omitempty"`
+
+	// MaxSessionsPerNode specifies the maximum number of BackupSessions that can run at the same time
+	// on a single node. If not specified, there is no limit.
+	// +optional
+	MaxSessionsPerNode *int32 `json:"maxSessionsPerNode,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&KubeStashConfig{})
 }
@@ -52,6 +52,36 @@ func (in *BackendMeta) DeepCopy() *BackendMeta {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConcurrencyLimits) DeepCopyInto(out *ConcurrencyLimits) {
+	*out = *in
+	if in.MaxSessionsPerBackupStorage != nil {
+		in, out := &in.MaxSessionsPerBackupStorage, &out.MaxSessionsPerBackupStorage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSessionsPerNamespace != nil {
+		in, out := &in.MaxSessionsPerNamespace, &out.MaxSessionsPerNamespace
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSessionsPerNode != nil {
+		in, out := &in.MaxSessionsPerNode, &out.MaxSessionsPerNode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyLimits.
+func (in *ConcurrencyLimits) DeepCopy() *ConcurrencyLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ConcurrencyLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerConfigurationSpec) DeepCopyInto(out *ControllerConfigurationSpec) {
 	*out = *in
@@ -241,6 +271,11 @@ func (in *KubeStashConfig) DeepCopyInto(out *KubeStashConfig) {
 		copy(*out, *in)
 	}
 	out.NetVolAccessor = in.NetVolAccessor
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(ConcurrencyLimits)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStashConfig.
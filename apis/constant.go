@@ -41,6 +41,7 @@ const (
 	KindReplicationController = "ReplicationController"
 	KindJob                   = "Job"
 	KindVolumeSnapshot        = "VolumeSnapshot"
+	KindVolumeGroupSnapshot   = "VolumeGroupSnapshot"
 	KindNamespace             = "Namespace"
 	KindEmpty                 = ""
 )
@@ -157,12 +158,15 @@ const (
 	KeyInterimVolume     = "INTERIM_VOLUME"
 	KeyResticCacheVolume = "RESTIC_CACHE_VOLUME"
 
-	ResticCacheVolumeName = TempDirVolumeName
-	InterimVolumeName     = "kubestash-interim-volume"
-	OwnerKey              = ".metadata.controller"
-	SnapshotVersionV1     = "v1"
-	DirRepository         = "repository"
-	DirSnapshots          = "snapshots"
+	ResticCacheVolumeName  = TempDirVolumeName
+	InterimVolumeName      = "kubestash-interim-volume"
+	OwnerKey               = ".metadata.controller"
+	SnapshotVersionV1      = "v1"
+	DirRepository          = "repository"
+	DirSnapshots           = "snapshots"
+	DirVerificationReports = "verification-reports"
+	FileBackendMeta        = "metadata.yaml"
+	FileRepositoryMeta     = "repository.yaml"
 
 	SessionFullBackup     = "full-backup"
 	SessionManifestBackup = "manifest-backup"
@@ -217,6 +221,59 @@ var (
 	PriorityClasses           = schema.GroupResource{Group: "scheduling.k8s.io", Resource: "priorityclasses"}
 )
 
+const (
+	// HookOutputPrefix is the prefix of the lines of the hook output that emit a named value
+	HookOutputPrefix = "kubestash-output:"
+	// HookOutputVariablePrefix is the prefix of the variables holding the hook outputs
+	HookOutputVariablePrefix = "HOOK_"
+	// HookStagePre and HookStagePost separate the output variables of the pre and post hooks
+	HookStagePre  = "PRE"
+	HookStagePost = "POST"
+	// MaxHookStdoutSize is the maximum size of the hook output that is recorded in the status
+	MaxHookStdoutSize = 1024
+	// MaxUndoHookAttempts is the number of times an undo hook is attempted before it is marked as failed
+	MaxUndoHookAttempts = 5
+	// MaxVerificationOutputSize is the maximum size of the verification script output that is recorded in the status
+	MaxVerificationOutputSize = 4096
+	// MaxRestoredFileListSize is the maximum number of restored files of a component that are recorded in the status
+	MaxRestoredFileListSize = 100
+)
+
+const (
+	// ParamKeyPathFilter is the parameter through which the path filter of a component is passed to the restore task
+	// as a JSON encoded string
+	ParamKeyPathFilter = "pathFilter"
+)
+
+const (
+	// TypePaused indicates that the resource has been paused
+	TypePaused = "Paused"
+	// ReasonPaused indicates that the resource has been paused as a whole
+	ReasonPaused = "Paused"
+	// ReasonSessionsPaused indicates that only some of the sessions of the backup invoker have been paused
+	ReasonSessionsPaused = "SessionsPaused"
+)
+
+// Pod role related constants
+const (
+	KubeDBRoleLabelKey = KubeDBGroupName + "/role"
+	RolePrimary        = "primary"
+	RoleLeader         = "leader"
+)
+
+// Backup verification sandbox related constants
+const (
+	KubeStashSandbox                = "kubestash.com/sandbox"
+	KeySandboxCreationTime          = "kubestash.com/sandbox-creation-time"
+	KeySandboxExpiryTime            = "kubestash.com/sandbox-expiry-time"
+	DefaultSandboxPrefix            = "kubestash-sandbox"
+	DefaultSandboxTTL               = 2 * time.Hour
+	DefaultSandboxOperatorNamespace = "kubedb"
+	SandboxNetworkPolicyName        = "kubestash-sandbox-isolation"
+	SandboxRestoreEgressPolicyName  = "kubestash-sandbox-restore-egress"
+	SandboxResourceQuotaName        = "kubestash-sandbox-quota"
+)
+
 // DefaultNonRestorableResources lists resources that are not restorable by default.
 var DefaultNonRestorableResources = []string{
 	"nodes",
@@ -17,6 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/crds"
 
 	kmapi "kmodules.xyz/client-go/api/v1"
@@ -35,3 +39,299 @@ func (bb BackupBatch) HasTarget(target kmapi.TypedObjectReference) bool {
 	}
 	return false
 }
+
+// GetSession returns the session with the given name
+func (bb *BackupBatch) GetSession(name string) *BatchSession {
+	for i := range bb.Spec.Sessions {
+		if bb.Spec.Sessions[i].SessionConfig != nil && bb.Spec.Sessions[i].Name == name {
+			return &bb.Spec.Sessions[i]
+		}
+	}
+	return nil
+}
+
+// GetStages returns the stages of the session. If no stage is specified,
+// all the targets are returned in a single stage.
+func (s *BatchSession) GetStages() []BatchStage {
+	if len(s.Stages) > 0 {
+		return s.Stages
+	}
+	stage := BatchStage{Name: "default"}
+	for _, t := range s.Targets {
+		stage.Targets = append(stage.Targets, t.Name)
+	}
+	return []BatchStage{stage}
+}
+
+// InitializeStageStatus resets the progress of the stages of the given session for a new backup.
+func (bb *BackupBatch) InitializeStageStatus(sessionName string) error {
+	session := bb.GetSession(sessionName)
+	if session == nil {
+		return fmt.Errorf("session %q not found", sessionName)
+	}
+
+	stages := make([]BatchStageStatus, 0, len(bb.Status.Stages))
+	for _, stage := range bb.Status.Stages {
+		if stage.Session != sessionName {
+			stages = append(stages, stage)
+		}
+	}
+	for _, stage := range session.GetStages() {
+		status := BatchStageStatus{
+			Session: sessionName,
+			Name:    stage.Name,
+			Phase:   BatchExecutionPending,
+		}
+		for _, target := range stage.Targets {
+			status.Targets = append(status.Targets, BatchTargetStatus{Name: target, Phase: BatchExecutionPending})
+		}
+		stages = append(stages, status)
+	}
+	bb.Status.Stages = stages
+	return nil
+}
+
+// GetNextTargets returns the targets of the given session whose backup can be started now.
+// The targets are selected from the first stage that has not completed yet, limited by the parallelism of the stage.
+func (bb *BackupBatch) GetNextTargets(sessionName string) []string {
+	session := bb.GetSession(sessionName)
+	if session == nil {
+		return nil
+	}
+
+	for _, stage := range session.GetStages() {
+		status := bb.getStageStatus(sessionName, stage.Name)
+		if status == nil || status.Phase.isCompleted() {
+			continue
+		}
+
+		running := 0
+		var pending []string
+		for _, target := range status.Targets {
+			switch target.Phase {
+			case BatchExecutionRunning:
+				running++
+			case BatchExecutionPending:
+				pending = append(pending, target.Name)
+			}
+		}
+		if stage.Parallelism != nil {
+			available := max(int(*stage.Parallelism)-running, 0)
+			pending = pending[:min(available, len(pending))]
+		}
+		return pending
+	}
+	return nil
+}
+
+// SetTargetPhase updates the phase of the given target and the phase of its stage.
+// If the stage fails and its failure policy is "Stop", the following stages are skipped.
+func (bb *BackupBatch) SetTargetPhase(sessionName, target string, phase BatchExecutionPhase, reason string) error {
+	session := bb.GetSession(sessionName)
+	if session == nil {
+		return fmt.Errorf("session %q not found", sessionName)
+	}
+
+	stages := session.GetStages()
+	for i, stage := range stages {
+		status := bb.getStageStatus(sessionName, stage.Name)
+		if status == nil {
+			continue
+		}
+		for j := range status.Targets {
+			if status.Targets[j].Name != target {
+				continue
+			}
+			status.Targets[j].Phase = phase
+			status.Targets[j].Reason = reason
+			status.Phase = status.calculatePhase()
+
+			if status.Phase == BatchExecutionFailed && stage.FailurePolicy != StageFailurePolicyContinue {
+				bb.skipStages(sessionName, stages[i+1:], fmt.Sprintf("stage %q failed", stage.Name))
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("target %q not found in the stages of session %q", target, sessionName)
+}
+
+// GetStagesPhase returns the overall phase of the stages of the given session
+func (bb *BackupBatch) GetStagesPhase(sessionName string) BatchExecutionPhase {
+	phase := BatchExecutionSucceeded
+	found := false
+	for _, stage := range bb.Status.Stages {
+		if stage.Session != sessionName {
+			continue
+		}
+		found = true
+		switch stage.Phase {
+		case BatchExecutionFailed:
+			phase = BatchExecutionFailed
+		case BatchExecutionPending, BatchExecutionRunning:
+			if phase != BatchExecutionFailed {
+				phase = BatchExecutionRunning
+			}
+		}
+	}
+	if !found {
+		return BatchExecutionPending
+	}
+	return phase
+}
+
+// GetBatchPreHooks returns the batch pre-backup hooks of the given session.
+// They must be executed before the first stage of the session starts.
+func (bb *BackupBatch) GetBatchPreHooks(sessionName string) []HookInfo {
+	session := bb.GetSession(sessionName)
+	if session == nil || session.BatchHooks == nil {
+		return nil
+	}
+	return session.BatchHooks.PreBackup
+}
+
+// GetBatchPostHooks returns the batch post-backup hooks of the given session that must be executed now.
+// Nothing is returned until all the stages of the session have completed. Then, the hooks are selected
+// by their execution policy according to whether any of the stages has failed.
+func (bb *BackupBatch) GetBatchPostHooks(sessionName string) []HookInfo {
+	session := bb.GetSession(sessionName)
+	if session == nil || session.BatchHooks == nil || !bb.stagesCompleted(sessionName) {
+		return nil
+	}
+
+	failed := bb.GetStagesPhase(sessionName) == BatchExecutionFailed
+	var hooks []HookInfo
+	for _, hook := range session.BatchHooks.PostBackup {
+		switch hook.ExecutionPolicy {
+		case ExecuteOnSuccess:
+			if failed {
+				continue
+			}
+		case ExecuteOnFailure:
+			if !failed {
+				continue
+			}
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks
+}
+
+func (bb *BackupBatch) stagesCompleted(sessionName string) bool {
+	found := false
+	for _, stage := range bb.Status.Stages {
+		if stage.Session != sessionName {
+			continue
+		}
+		if !stage.Phase.isCompleted() {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+func (bb *BackupBatch) skipStages(sessionName string, stages []BatchStage, reason string) {
+	for _, stage := range stages {
+		status := bb.getStageStatus(sessionName, stage.Name)
+		if status == nil {
+			continue
+		}
+		for j := range status.Targets {
+			if status.Targets[j].Phase == BatchExecutionPending {
+				status.Targets[j].Phase = BatchExecutionSkipped
+				status.Targets[j].Reason = reason
+			}
+		}
+		status.Phase = status.calculatePhase()
+	}
+}
+
+func (bb *BackupBatch) getStageStatus(sessionName, stageName string) *BatchStageStatus {
+	for i := range bb.Status.Stages {
+		if bb.Status.Stages[i].Session == sessionName && bb.Status.Stages[i].Name == stageName {
+			return &bb.Status.Stages[i]
+		}
+	}
+	return nil
+}
+
+func (s *BatchStageStatus) calculatePhase() BatchExecutionPhase {
+	var pending, running, failed, skipped int
+	for _, t := range s.Targets {
+		switch t.Phase {
+		case BatchExecutionPending, "":
+			pending++
+		case BatchExecutionRunning:
+			running++
+		case BatchExecutionFailed:
+			failed++
+		case BatchExecutionSkipped:
+			skipped++
+		}
+	}
+
+	switch {
+	case skipped == len(s.Targets):
+		return BatchExecutionSkipped
+	case pending == len(s.Targets):
+		return BatchExecutionPending
+	case running > 0 || pending > 0:
+		return BatchExecutionRunning
+	case failed > 0:
+		return BatchExecutionFailed
+	default:
+		return BatchExecutionSucceeded
+	}
+}
+
+func (p BatchExecutionPhase) isCompleted() bool {
+	return p == BatchExecutionSucceeded || p == BatchExecutionFailed || p == BatchExecutionSkipped
+}
+
+// IsPaused returns whether all the sessions of the BackupBatch are paused at the given time
+func (bb *BackupBatch) IsPaused(now time.Time) bool {
+	return apis.IsPaused(bb.Spec.Paused, bb.Spec.PauseInfo, now)
+}
+
+// IsSessionPaused returns whether the given session of the BackupBatch is paused at the given time
+func (bb *BackupBatch) IsSessionPaused(session string, now time.Time) bool {
+	return apis.IsPaused(bb.Spec.Paused, bb.Spec.PauseInfo, now, session)
+}
+
+// Pause pauses all the sessions of the BackupBatch. It returns the previous pause details,
+// so that the original state can be restored with RestorePauseState.
+func (bb *BackupBatch) Pause(info apis.PauseInfo) *apis.PauseInfo {
+	var original *apis.PauseInfo
+	if bb.Spec.Paused {
+		original = &apis.PauseInfo{}
+		if bb.Spec.PauseInfo != nil {
+			original = bb.Spec.PauseInfo.DeepCopy()
+		}
+	}
+
+	info.Sessions = nil
+	bb.Spec.Paused = true
+	bb.Spec.PauseInfo = &info
+	return original
+}
+
+// RestorePauseState restores the pause details returned by Pause. A nil value resumes the BackupBatch.
+func (bb *BackupBatch) RestorePauseState(original *apis.PauseInfo) {
+	if original == nil {
+		bb.Resume()
+		return
+	}
+	bb.Spec.Paused = true
+	bb.Spec.PauseInfo = original
+}
+
+// Resume resumes all the sessions of the BackupBatch
+func (bb *BackupBatch) Resume() {
+	bb.Spec.Paused = false
+	bb.Spec.PauseInfo = nil
+}
+
+// SetPausedCondition reflects the pause state of the BackupBatch at the given time in its conditions
+func (bb *BackupBatch) SetPausedCondition(now time.Time) {
+	bb.Status.Conditions = apis.SetPausedCondition(bb.Status.Conditions, bb.Spec.Paused, bb.Spec.PauseInfo, now)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestBackupBatchStages(t *testing.T) {
+	bb := sampleBackupBatch([]BatchStage{
+		{Name: "database", Targets: []string{"mysql", "redis"}, Parallelism: ptr.To(int32(1))},
+		{Name: "application", Targets: []string{"app"}},
+	})
+	assert.Nil(t, bb.InitializeStageStatus("daily"))
+
+	assert.Equal(t, []string{"mysql"}, bb.GetNextTargets("daily"))
+	assert.Nil(t, bb.SetTargetPhase("daily", "mysql", BatchExecutionRunning, ""))
+	assert.Empty(t, bb.GetNextTargets("daily"))
+	assert.Equal(t, BatchExecutionRunning, bb.Status.Stages[0].Phase)
+
+	assert.Nil(t, bb.SetTargetPhase("daily", "mysql", BatchExecutionSucceeded, ""))
+	assert.Equal(t, []string{"redis"}, bb.GetNextTargets("daily"))
+	assert.Nil(t, bb.SetTargetPhase("daily", "redis", BatchExecutionSucceeded, ""))
+	assert.Equal(t, BatchExecutionSucceeded, bb.Status.Stages[0].Phase)
+
+	assert.Equal(t, []string{"app"}, bb.GetNextTargets("daily"))
+	assert.Equal(t, BatchExecutionRunning, bb.GetStagesPhase("daily"))
+	assert.Nil(t, bb.SetTargetPhase("daily", "app", BatchExecutionSucceeded, ""))
+	assert.Equal(t, BatchExecutionSucceeded, bb.GetStagesPhase("daily"))
+	assert.Empty(t, bb.GetNextTargets("daily"))
+
+	assert.NotNil(t, bb.SetTargetPhase("daily", "unknown", BatchExecutionRunning, ""))
+}
+
+func TestBackupBatchStageFailurePolicy(t *testing.T) {
+	tests := []struct {
+		name                string
+		policy              StageFailurePolicy
+		expectedNextTargets []string
+		expectedAppPhase    BatchExecutionPhase
+		expectedStagesPhase BatchExecutionPhase
+	}{
+		{
+			name:                "Following stages should be skipped if a stage fails with the default policy",
+			expectedAppPhase:    BatchExecutionSkipped,
+			expectedStagesPhase: BatchExecutionFailed,
+		},
+		{
+			name:                "Following stages should be executed if a stage fails with Continue policy",
+			policy:              StageFailurePolicyContinue,
+			expectedNextTargets: []string{"app"},
+			expectedAppPhase:    BatchExecutionPending,
+			expectedStagesPhase: BatchExecutionFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bb := sampleBackupBatch([]BatchStage{
+				{Name: "database", Targets: []string{"mysql", "redis"}, FailurePolicy: test.policy},
+				{Name: "application", Targets: []string{"app"}},
+			})
+			assert.Nil(t, bb.InitializeStageStatus("daily"))
+			assert.Nil(t, bb.SetTargetPhase("daily", "mysql", BatchExecutionFailed, "dump failed"))
+			assert.Nil(t, bb.SetTargetPhase("daily", "redis", BatchExecutionSucceeded, ""))
+
+			assert.Equal(t, BatchExecutionFailed, bb.Status.Stages[0].Phase)
+			assert.Equal(t, test.expectedAppPhase, bb.Status.Stages[1].Targets[0].Phase)
+			assert.Equal(t, test.expectedNextTargets, bb.GetNextTargets("daily"))
+			assert.Equal(t, test.expectedStagesPhase, bb.GetStagesPhase("daily"))
+		})
+	}
+}
+
+func TestBackupBatchDefaultStage(t *testing.T) {
+	bb := sampleBackupBatch(nil)
+	assert.Nil(t, bb.InitializeStageStatus("daily"))
+	assert.Len(t, bb.Status.Stages, 1)
+	assert.Equal(t, []string{"mysql", "redis", "app"}, bb.GetNextTargets("daily"))
+}
+
+func TestBackupBatchHooks(t *testing.T) {
+	tests := []struct {
+		name              string
+		mysqlPhase        BatchExecutionPhase
+		expectedPostHooks []string
+	}{
+		{
+			name:              "Post hooks with Always and OnSuccess policy should be executed if all the stages succeeded",
+			mysqlPhase:        BatchExecutionSucceeded,
+			expectedPostHooks: []string{"resume", "notify-success"},
+		},
+		{
+			name:              "Post hooks with Always and OnFailure policy should be executed if any stage failed",
+			mysqlPhase:        BatchExecutionFailed,
+			expectedPostHooks: []string{"resume", "notify-failure"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bb := sampleBackupBatch([]BatchStage{
+				{Name: "database", Targets: []string{"mysql", "redis"}},
+				{Name: "application", Targets: []string{"app"}},
+			})
+			bb.Spec.Sessions[0].BatchHooks = &BackupHooks{
+				PreBackup: []HookInfo{{Name: "quiesce"}},
+				PostBackup: []HookInfo{
+					{Name: "resume", ExecutionPolicy: ExecuteAlways},
+					{Name: "notify-success", ExecutionPolicy: ExecuteOnSuccess},
+					{Name: "notify-failure", ExecutionPolicy: ExecuteOnFailure},
+				},
+			}
+			assert.Nil(t, bb.InitializeStageStatus("daily"))
+			assert.Equal(t, []HookInfo{{Name: "quiesce"}}, bb.GetBatchPreHooks("daily"))
+
+			assert.Nil(t, bb.SetTargetPhase("daily", "mysql", test.mysqlPhase, ""))
+			assert.Nil(t, bb.SetTargetPhase("daily", "redis", BatchExecutionSucceeded, ""))
+			if test.mysqlPhase == BatchExecutionSucceeded {
+				assert.Empty(t, bb.GetBatchPostHooks("daily"), "post hooks should wait for the following stages")
+				assert.Nil(t, bb.SetTargetPhase("daily", "app", BatchExecutionSucceeded, ""))
+			}
+
+			var names []string
+			for _, hook := range bb.GetBatchPostHooks("daily") {
+				names = append(names, hook.Name)
+			}
+			assert.Equal(t, test.expectedPostHooks, names)
+		})
+	}
+}
+
+func sampleBackupBatch(stages []BatchStage) *BackupBatch {
+	return &BackupBatch{
+		Spec: BackupBatchSpec{
+			Sessions: []BatchSession{
+				{
+					SessionConfig: &SessionConfig{Name: "daily"},
+					Targets:       []TargetBackupSpec{{Name: "mysql"}, {Name: "redis"}, {Name: "app"}},
+					Stages:        stages,
+				},
+			},
+		},
+	}
+}
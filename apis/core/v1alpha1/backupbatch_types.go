@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"kubestash.dev/apimachinery/apis"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kmapi "kmodules.xyz/client-go/api/v1"
 )
@@ -66,6 +68,12 @@ type BackupBatchSpec struct {
 	// skip processing any further events for this BackupBatch.
 	// +optional
 	Paused bool `json:"paused,omitempty"`
+
+	// PauseInfo specifies the details of the pause, i.e. why and by whom the BackupBatch was paused and when it should be resumed.
+	// It is only effective when `paused` is set to `true`.
+	// If `sessions` is specified, only those sessions are paused.
+	// +optional
+	PauseInfo *apis.PauseInfo `json:"pauseInfo,omitempty"`
 }
 
 // TargetReference specifies a reference to the target that is subject to backup
@@ -83,8 +91,54 @@ type BatchSession struct {
 
 	// Targets specifies a list of target backup specification.
 	Targets []TargetBackupSpec `json:"targets,omitempty"`
+
+	// Stages specifies the order the targets are backed up in. The stages are executed one after another,
+	// while the targets of a stage are backed up in parallel.
+	// If not specified, all the targets are backed up in parallel in a single stage.
+	// +optional
+	Stages []BatchStage `json:"stages,omitempty"`
+
+	// BatchHooks specifies the hooks that wrap the backup of all the targets. The pre-backup hooks are executed
+	// once before the first stage and the post-backup hooks are executed once after the last stage,
+	// i.e. to quiesce an application before its components are backed up and resume it afterward.
+	// The post-backup hooks are selected by their execution policy according to whether any stage has failed.
+	// +optional
+	BatchHooks *BackupHooks `json:"batchHooks,omitempty"`
 }
 
+// BatchStage specifies a group of targets that are backed up together
+type BatchStage struct {
+	// Name specifies the name of the stage
+	Name string `json:"name"`
+
+	// Targets specifies the identifiers of the targets that are backed up in this stage.
+	// They should match the names used in the `targets` section of the session.
+	Targets []string `json:"targets"`
+
+	// Parallelism specifies the maximum number of targets of this stage that are backed up at the same time.
+	// If not specified, all the targets of the stage are backed up at the same time.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Parallelism *int32 `json:"parallelism,omitempty"`
+
+	// FailurePolicy specifies what to do with the following stages if the backup of any target of this stage fails.
+	// The valid values are:
+	// - "Stop": The following stages are skipped. This is the default behavior.
+	// - "Continue": The following stages are executed regardless of the failure.
+	// +kubebuilder:default=Stop
+	// +optional
+	FailurePolicy StageFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// StageFailurePolicy specifies what to do with the following stages if a stage fails
+// +kubebuilder:validation:Enum=Stop;Continue
+type StageFailurePolicy string
+
+const (
+	StageFailurePolicyStop     StageFailurePolicy = "Stop"
+	StageFailurePolicyContinue StageFailurePolicy = "Continue"
+)
+
 // TargetBackupSpec specifies the information needed to backup a target.
 type TargetBackupSpec struct {
 	// Name points to the identifier of the target that is being backed up.
@@ -112,11 +166,58 @@ type BackupBatchStatus struct {
 	// +optional
 	Targets []ResourceFoundStatus `json:"targets,omitempty"`
 
+	// Stages specifies the progress of the stages of the latest backup of each session
+	// +optional
+	Stages []BatchStageStatus `json:"stages,omitempty"`
+
 	// Conditions represents list of conditions regarding this BackupConfiguration
 	// +optional
 	Conditions []kmapi.Condition `json:"conditions,omitempty"`
 }
 
+// BatchStageStatus specifies the progress of a stage of a BatchSession
+type BatchStageStatus struct {
+	// Session specifies the name of the session the stage belongs to
+	Session string `json:"session"`
+
+	// Name specifies the name of the stage
+	Name string `json:"name"`
+
+	// Phase specifies the current state of the stage
+	// +optional
+	Phase BatchExecutionPhase `json:"phase,omitempty"`
+
+	// Targets specifies the progress of the targets of the stage
+	// +optional
+	Targets []BatchTargetStatus `json:"targets,omitempty"`
+}
+
+// BatchTargetStatus specifies the progress of the backup of a target in a stage
+type BatchTargetStatus struct {
+	// Name specifies the identifier of the target
+	Name string `json:"name"`
+
+	// Phase specifies the current state of the backup of the target
+	// +optional
+	Phase BatchExecutionPhase `json:"phase,omitempty"`
+
+	// Reason specifies why the backup of the target failed or was skipped
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// BatchExecutionPhase specifies the current state of a stage or target of a BatchSession
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed;Skipped
+type BatchExecutionPhase string
+
+const (
+	BatchExecutionPending   BatchExecutionPhase = "Pending"
+	BatchExecutionRunning   BatchExecutionPhase = "Running"
+	BatchExecutionSucceeded BatchExecutionPhase = "Succeeded"
+	BatchExecutionFailed    BatchExecutionPhase = "Failed"
+	BatchExecutionSkipped   BatchExecutionPhase = "Skipped"
+)
+
 //+kubebuilder:object:root=true
 
 // BackupBatchList contains a list of BackupBatch
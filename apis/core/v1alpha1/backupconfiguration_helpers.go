@@ -17,9 +17,20 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	"kubestash.dev/apimachinery/apis"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
 	"kubestash.dev/apimachinery/crds"
 
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	kmapi "kmodules.xyz/client-go/api/v1"
 	"kmodules.xyz/client-go/apiextensions"
 	cutil "kmodules.xyz/client-go/conditions"
@@ -104,3 +115,531 @@ func (b *BackupConfiguration) GetTargetRef() *kmapi.TypedObjectReference {
 	}
 	return b.Spec.Target
 }
+
+func (b *BackupConfiguration) GetSession(name string) *Session {
+	for i := range b.Spec.Sessions {
+		if b.Spec.Sessions[i].SessionConfig != nil && b.Spec.Sessions[i].Name == name {
+			return &b.Spec.Sessions[i]
+		}
+	}
+	return nil
+}
+
+// SetNextSchedule records the next permitted run of the given session after the given time in the session status.
+func (b *BackupConfiguration) SetNextSchedule(sessionName string, after time.Time) error {
+	session := b.GetSession(sessionName)
+	if session == nil || session.Scheduler == nil {
+		return fmt.Errorf("no scheduler found for session %q", sessionName)
+	}
+	effective, err := session.Scheduler.GetEffectiveSchedule(b.UID)
+	if err != nil {
+		return err
+	}
+	next, err := session.Scheduler.NextSchedule(b.UID, after)
+	if err != nil {
+		return err
+	}
+
+	status := b.GetSessionStatus(sessionName)
+	status.EffectiveSchedule = effective
+	status.NextSchedule = next.Format(time.RFC3339)
+	return nil
+}
+
+// GetBackupType chooses the type of the backup to take at the given time according to the backup type policy.
+// The last Snapshot refers to the latest successful Snapshot of the session the new backup would build on.
+func (s *SessionConfig) GetBackupType(last *storageapi.Snapshot, now time.Time) (storageapi.BackupType, error) {
+	policy := s.BackupTypePolicy
+	if policy == nil || last == nil {
+		return storageapi.BackupTypeFull, nil
+	}
+
+	if policy.MaxIncrementals != nil && last.GetChainLength() >= *policy.MaxIncrementals {
+		return storageapi.BackupTypeFull, nil
+	}
+
+	if policy.FullBackupSchedule != "" {
+		lastFull := last.GetFullBackupTime()
+		if lastFull == nil {
+			return storageapi.BackupTypeFull, nil
+		}
+		schedule, err := policy.ParseFullBackupSchedule()
+		if err != nil {
+			return "", fmt.Errorf("invalid full backup schedule %q: %w", policy.FullBackupSchedule, err)
+		}
+		loc := time.Local
+		if s.Scheduler != nil {
+			if loc, err = s.Scheduler.GetLocation(); err != nil {
+				return "", fmt.Errorf("invalid time zone %q: %w", *s.Scheduler.TimeZone, err)
+			}
+		}
+		if !schedule.Next(lastFull.In(loc)).After(now) {
+			return storageapi.BackupTypeFull, nil
+		}
+	}
+
+	return storageapi.BackupTypeIncremental, nil
+}
+
+func (p *BackupTypePolicy) ParseFullBackupSchedule() (cron.Schedule, error) {
+	return cron.ParseStandard(p.FullBackupSchedule)
+}
+
+// GetTriggerStatus returns the status of the given trigger, adding a new entry if it does not exist.
+func (s *SessionStatus) GetTriggerStatus(name string) *TriggerStatus {
+	for i := range s.Triggers {
+		if s.Triggers[i].Name == name {
+			return &s.Triggers[i]
+		}
+	}
+	s.Triggers = append(s.Triggers, TriggerStatus{Name: name})
+	return &s.Triggers[len(s.Triggers)-1]
+}
+
+// RecordEvent records an event observed for the trigger. The event triggers a backup once
+// the debounce period is over and the rate limit allows it.
+func (s *TriggerStatus) RecordEvent(eventTime time.Time) {
+	s.LastEventTime = &metav1.Time{Time: eventTime}
+	s.PendingEvent = true
+}
+
+// ShouldTrigger returns whether a backup should be triggered now for the pending event of the trigger.
+// If the backup has to be delayed due to debounce or rate limit, the duration to wait is also returned.
+func (t *BackupTrigger) ShouldTrigger(status TriggerStatus, now time.Time) (bool, time.Duration) {
+	if !status.PendingEvent {
+		return false, 0
+	}
+
+	if t.Debounce != nil && status.LastEventTime != nil {
+		if settleTime := status.LastEventTime.Add(t.Debounce.Duration); now.Before(settleTime) {
+			return false, settleTime.Sub(now)
+		}
+	}
+
+	if t.RateLimit != nil {
+		recent := t.recentTriggeredTimes(status.TriggeredTimes, now)
+		if int32(len(recent)) >= t.RateLimit.MaxBackups {
+			return false, recent[0].Add(t.RateLimit.Period.Duration).Sub(now)
+		}
+	}
+	return true, 0
+}
+
+// RecordTriggered records that a backup has been triggered for the pending event of the trigger.
+func (t *BackupTrigger) RecordTriggered(status *TriggerStatus, now time.Time) {
+	status.PendingEvent = false
+	status.TriggeredTimes = append(t.recentTriggeredTimes(status.TriggeredTimes, now), metav1.Time{Time: now})
+}
+
+// recentTriggeredTimes returns the triggered times that are counted in the current rate limit period
+func (t *BackupTrigger) recentTriggeredTimes(times []metav1.Time, now time.Time) []metav1.Time {
+	if t.RateLimit == nil {
+		return nil
+	}
+	var recent []metav1.Time
+	for _, tt := range times {
+		if now.Sub(tt.Time) < t.RateLimit.Period.Duration {
+			recent = append(recent, tt)
+		}
+	}
+	return recent
+}
+
+// GetSessionStatus returns the status of the given session, adding a new entry if it does not exist.
+func (b *BackupConfiguration) GetSessionStatus(sessionName string) *SessionStatus {
+	for i := range b.Status.Sessions {
+		if b.Status.Sessions[i].Name == sessionName {
+			return &b.Status.Sessions[i]
+		}
+	}
+	b.Status.Sessions = append(b.Status.Sessions, SessionStatus{Name: sessionName})
+	return &b.Status.Sessions[len(b.Status.Sessions)-1]
+}
+
+// RecordBackupResult updates the last success and failure times and the consecutive failure count
+// of the session from a completed BackupSession.
+func (s *SessionStatus) RecordBackupResult(phase BackupSessionPhase, completionTime time.Time) {
+	switch phase {
+	case BackupSessionSucceeded, BackupSessionPartiallySucceeded:
+		s.LastSuccessTime = &metav1.Time{Time: completionTime}
+		s.ConsecutiveFailures = 0
+	case BackupSessionFailed:
+		s.LastFailureTime = &metav1.Time{Time: completionTime}
+		s.ConsecutiveFailures++
+	}
+}
+
+// GetLastSuccessfulBackupTime returns the time of the latest successful backup of the given session
+// computed from the LastBackupTime of its Repositories and the successful Snapshots taken by the session.
+func GetLastSuccessfulBackupTime(session *Session, repositories []storageapi.Repository, snapshots []storageapi.Snapshot) *metav1.Time {
+	repoNames := make(map[string]bool, len(session.Repositories))
+	for _, repo := range session.Repositories {
+		repoNames[repo.Name] = true
+	}
+
+	var last *metav1.Time
+	update := func(t *metav1.Time) {
+		if t != nil && (last == nil || last.Before(t)) {
+			last = t
+		}
+	}
+	for _, repo := range repositories {
+		if repoNames[repo.Name] {
+			update(repo.Status.LastBackupTime)
+		}
+	}
+	for _, snap := range snapshots {
+		if snap.Spec.Session == session.Name && repoNames[snap.Spec.Repository] && snap.Status.Phase == storageapi.SnapshotSucceeded {
+			update(snap.Status.SnapshotTime)
+		}
+	}
+	return last
+}
+
+// UpdateRPOCompliance evaluates the recovery point objective of the given session against the latest
+// successful backup computed from the Repositories and Snapshots, and sets the "RPOViolated" condition
+// in the session status as well as in the BackupConfiguration status. It returns whether the objective is violated.
+func (b *BackupConfiguration) UpdateRPOCompliance(sessionName string, repositories []storageapi.Repository, snapshots []storageapi.Snapshot, now time.Time) (bool, error) {
+	session := b.GetSession(sessionName)
+	if session == nil {
+		return false, fmt.Errorf("session %q not found", sessionName)
+	}
+	status := b.GetSessionStatus(sessionName)
+	if last := GetLastSuccessfulBackupTime(session, repositories, snapshots); last != nil &&
+		(status.LastSuccessTime == nil || status.LastSuccessTime.Before(last)) {
+		status.LastSuccessTime = last
+	}
+	if session.RPO == nil {
+		return false, nil
+	}
+
+	newCond := kmapi.Condition{
+		Type:    TypeRPOViolated,
+		Status:  metav1.ConditionFalse,
+		Reason:  ReasonRPOSatisfied,
+		Message: fmt.Sprintf("Latest successful backup is within the recovery point objective of %s.", session.RPO.Duration),
+	}
+	switch {
+	case status.LastSuccessTime == nil:
+		newCond.Status = metav1.ConditionTrue
+		newCond.Reason = ReasonRPOViolated
+		newCond.Message = fmt.Sprintf("No successful backup found. Recovery point objective is %s.", session.RPO.Duration)
+	case now.Sub(status.LastSuccessTime.Time) > session.RPO.Duration:
+		newCond.Status = metav1.ConditionTrue
+		newCond.Reason = ReasonRPOViolated
+		newCond.Message = fmt.Sprintf("Latest successful backup was taken at %s, which exceeds the recovery point objective of %s.",
+			status.LastSuccessTime.Format(time.RFC3339), session.RPO.Duration)
+	}
+	status.Conditions = cutil.SetCondition(status.Conditions, newCond)
+	b.setRPOViolatedCondition()
+	return newCond.Status == metav1.ConditionTrue, nil
+}
+
+// setRPOViolatedCondition aggregates the RPO compliance of the sessions into the BackupConfiguration status
+func (b *BackupConfiguration) setRPOViolatedCondition() {
+	var violated []string
+	for _, status := range b.Status.Sessions {
+		if cutil.IsConditionTrue(status.Conditions, TypeRPOViolated) {
+			violated = append(violated, status.Name)
+		}
+	}
+
+	newCond := kmapi.Condition{
+		Type:    TypeRPOViolated,
+		Status:  metav1.ConditionFalse,
+		Reason:  ReasonRPOSatisfied,
+		Message: "All the sessions satisfy their recovery point objectives.",
+	}
+	if len(violated) > 0 {
+		newCond.Status = metav1.ConditionTrue
+		newCond.Reason = ReasonRPOViolated
+		newCond.Message = fmt.Sprintf("Recovery point objective is violated for sessions: %s.", strings.Join(violated, ", "))
+	}
+	b.Status.Conditions = cutil.SetCondition(b.Status.Conditions, newCond)
+}
+
+// maxScheduleLookAhead limits how far NextSchedule searches for a permitted run
+const maxScheduleLookAhead = 366 * 24 * time.Hour
+
+// GetLocation returns the location the schedule, windows and blackout periods are evaluated in.
+func (s *SchedulerSpec) GetLocation() (*time.Location, error) {
+	if s.TimeZone == nil || *s.TimeZone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(*s.TimeZone)
+}
+
+// ParseSchedule parses the effective cron schedule of the invoker with the given UID.
+// The schedule is evaluated in the time zone of the scheduler.
+func (s *SchedulerSpec) ParseSchedule(uid types.UID) (cron.Schedule, error) {
+	schedule, err := s.GetEffectiveSchedule(uid)
+	if err != nil {
+		return nil, err
+	}
+	return cron.ParseStandard(schedule)
+}
+
+// GetEffectiveSchedule returns the cron schedule of the invoker with the given UID after replacing
+// the "H" fields with values derived from the UID. The CronJob can not parse the "H" fields,
+// so the backup triggering CronJob must be created with the effective schedule.
+// A "CRON_TZ=" or "TZ=" prefix of the schedule is kept as it is.
+func (s *SchedulerSpec) GetEffectiveSchedule(uid types.UID) (string, error) {
+	fields := strings.Fields(s.Schedule)
+	var prefix []string
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "CRON_TZ=") || strings.HasPrefix(fields[0], "TZ=")) {
+		prefix, fields = fields[:1], fields[1:]
+	}
+	if len(fields) != len(cronFieldBounds) {
+		// descriptors (i.e. "@daily") and malformed schedules are left for the cron parser to handle
+		return s.Schedule, nil
+	}
+
+	for i, field := range fields {
+		var values []string
+		for _, value := range strings.Split(field, ",") {
+			resolved, err := resolveHashField(value, cronFieldBounds[i], stableHash(uid, strconv.Itoa(i)))
+			if err != nil {
+				return "", fmt.Errorf("invalid field %q: %w", field, err)
+			}
+			values = append(values, resolved)
+		}
+		fields[i] = strings.Join(values, ",")
+	}
+	return strings.Join(append(prefix, fields...), " "), nil
+}
+
+// HasHashedFields returns whether any field of the schedule is an "H" field that must be resolved
+// using GetEffectiveSchedule before the schedule can be used by a CronJob.
+func (s *SchedulerSpec) HasHashedFields() bool {
+	for _, field := range strings.Fields(s.Schedule) {
+		for _, value := range strings.Split(field, ",") {
+			if strings.HasPrefix(value, "H") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetJitterOffset returns the stable delay of the invoker with the given UID from the scheduled time
+func (s *SchedulerSpec) GetJitterOffset(uid types.UID) time.Duration {
+	if s.Jitter == nil || s.Jitter.Duration < time.Second {
+		return 0
+	}
+	return time.Duration(stableHash(uid, "jitter")%uint32(s.Jitter.Duration/time.Second)) * time.Second
+}
+
+// cronFieldBounds holds the range of the minute, hour, day of month, month and day of week fields.
+// The day of month is limited to 28 so that the resolved value exists in every month. So, an "H" day
+// of month never resolves to 29-31, and an "H(<min>-<max>)" day of month beyond 28 is rejected.
+var cronFieldBounds = [][2]int{{0, 59}, {0, 23}, {1, 28}, {1, 12}, {0, 6}}
+
+func resolveHashField(value string, bounds [2]int, hash uint32) (string, error) {
+	if !strings.HasPrefix(value, "H") {
+		return value, nil
+	}
+
+	rangeExpr, stepExpr, hasStep := strings.Cut(strings.TrimPrefix(value, "H"), "/")
+	lower, upper := bounds[0], bounds[1]
+	if rangeExpr != "" {
+		if !strings.HasPrefix(rangeExpr, "(") || !strings.HasSuffix(rangeExpr, ")") {
+			return "", fmt.Errorf("expected H(<min>-<max>) but got %q", value)
+		}
+		minExpr, maxExpr, found := strings.Cut(strings.Trim(rangeExpr, "()"), "-")
+		if !found {
+			return "", fmt.Errorf("expected H(<min>-<max>) but got %q", value)
+		}
+		var err error
+		if lower, err = strconv.Atoi(minExpr); err != nil {
+			return "", err
+		}
+		if upper, err = strconv.Atoi(maxExpr); err != nil {
+			return "", err
+		}
+		if lower < bounds[0] || upper > bounds[1] || lower > upper {
+			return "", fmt.Errorf("range %d-%d is out of bounds %d-%d", lower, upper, bounds[0], bounds[1])
+		}
+	}
+
+	if !hasStep {
+		return strconv.Itoa(lower + int(hash%uint32(upper-lower+1))), nil
+	}
+	step, err := strconv.Atoi(stepExpr)
+	if err != nil || step < 1 {
+		return "", fmt.Errorf("invalid step %q", stepExpr)
+	}
+	start := lower + int(hash%uint32(min(step, upper-lower+1)))
+	return fmt.Sprintf("%d-%d/%d", start, upper, step), nil
+}
+
+func stableHash(uid types.UID, key string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(string(uid) + "/" + key))
+	return h.Sum32()
+}
+
+// NextSchedule returns the first scheduled time of the invoker with the given UID after the given time
+// that is inside the backup windows and outside the blackout periods. The jitter offset is included in the returned time.
+func (s *SchedulerSpec) NextSchedule(uid types.UID, after time.Time) (time.Time, error) {
+	schedule, err := s.ParseSchedule(uid)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule %q: %w", s.Schedule, err)
+	}
+	offset := s.GetJitterOffset(uid)
+	loc, err := s.GetLocation()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time zone %q: %w", *s.TimeZone, err)
+	}
+
+	t := after.In(loc).Add(-offset)
+	limit := t.Add(maxScheduleLookAhead)
+	for {
+		t = schedule.Next(t)
+		if t.IsZero() || t.After(limit) {
+			return time.Time{}, fmt.Errorf("no permitted schedule found within %s after %s", maxScheduleLookAhead, after.Format(time.RFC3339))
+		}
+		if reason, _ := s.skipReason(t.Add(offset)); reason == "" {
+			return t.Add(offset), nil
+		}
+	}
+}
+
+// GetSkipReason returns the condition reason and message explaining why a backup scheduled at
+// the given time must be skipped. An empty reason is returned if the backup is permitted.
+func (s *SchedulerSpec) GetSkipReason(t time.Time) (string, string, error) {
+	loc, err := s.GetLocation()
+	if err != nil {
+		return "", "", fmt.Errorf("invalid time zone %q: %w", *s.TimeZone, err)
+	}
+	reason, message := s.skipReason(t.In(loc))
+	return reason, message, nil
+}
+
+func (s *SchedulerSpec) skipReason(t time.Time) (string, string) {
+	for _, blackout := range s.Blackouts {
+		if blackout.contains(t) {
+			return ReasonSkippedInBlackoutPeriod, fmt.Sprintf("Backup scheduled at %s is inside the blackout period %q.", t.Format(time.RFC3339), blackout.Name)
+		}
+	}
+	if len(s.Windows) == 0 {
+		return "", ""
+	}
+	for _, window := range s.Windows {
+		if window.contains(t) {
+			return "", ""
+		}
+	}
+	return ReasonSkippedOutsideBackupWindow, fmt.Sprintf("Backup scheduled at %s is outside of the backup windows.", t.Format(time.RFC3339))
+}
+
+func (b BlackoutPeriod) contains(t time.Time) bool {
+	if b.Start != nil && t.Before(b.Start.Time) {
+		return false
+	}
+	if b.End != nil && !t.Before(b.End.Time) {
+		return false
+	}
+	if b.Recurring != nil {
+		return b.Recurring.contains(t)
+	}
+	return b.Start != nil || b.End != nil
+}
+
+func (w TimeWindow) contains(t time.Time) bool {
+	start, _ := ParseClockTime(w.StartTime)
+	end := 24 * time.Hour
+	if w.EndTime != "" {
+		end, _ = ParseClockTime(w.EndTime)
+	}
+	if end <= start {
+		end += 24 * time.Hour
+	}
+
+	// the window might have started on the previous day if it spans over midnight
+	for _, day := range []time.Time{t, t.AddDate(0, 0, -1)} {
+		midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, t.Location())
+		if !w.matchesDay(midnight) {
+			continue
+		}
+		if !t.Before(midnight.Add(start)) && t.Before(midnight.Add(end)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w TimeWindow) matchesDay(day time.Time) bool {
+	if len(w.Days) > 0 && !slices.Contains(w.Days, Weekday(day.Weekday().String())) {
+		return false
+	}
+	if len(w.DaysOfMonth) == 0 {
+		return true
+	}
+	daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+	for _, d := range w.DaysOfMonth {
+		if int(d) == day.Day() || (d < 0 && daysInMonth+int(d)+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClockTime parses a time of the day in "HH:MM" format and returns the duration since midnight.
+func ParseClockTime(clock string) (time.Duration, error) {
+	if clock == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM format", clock)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// IsPaused returns whether all the sessions of the BackupConfiguration are paused at the given time
+func (b *BackupConfiguration) IsPaused(now time.Time) bool {
+	return apis.IsPaused(b.Spec.Paused, b.Spec.PauseInfo, now)
+}
+
+// IsSessionPaused returns whether the given session of the BackupConfiguration is paused at the given time
+func (b *BackupConfiguration) IsSessionPaused(session string, now time.Time) bool {
+	return apis.IsPaused(b.Spec.Paused, b.Spec.PauseInfo, now, session)
+}
+
+// Pause pauses all the sessions of the BackupConfiguration. It returns the previous pause details,
+// so that the original state can be restored with RestorePauseState.
+func (b *BackupConfiguration) Pause(info apis.PauseInfo) *apis.PauseInfo {
+	var original *apis.PauseInfo
+	if b.Spec.Paused {
+		original = &apis.PauseInfo{}
+		if b.Spec.PauseInfo != nil {
+			original = b.Spec.PauseInfo.DeepCopy()
+		}
+	}
+
+	info.Sessions = nil
+	b.Spec.Paused = true
+	b.Spec.PauseInfo = &info
+	return original
+}
+
+// RestorePauseState restores the pause details returned by Pause. A nil value resumes the BackupConfiguration.
+func (b *BackupConfiguration) RestorePauseState(original *apis.PauseInfo) {
+	if original == nil {
+		b.Resume()
+		return
+	}
+	b.Spec.Paused = true
+	b.Spec.PauseInfo = original
+}
+
+// Resume resumes all the sessions of the BackupConfiguration
+func (b *BackupConfiguration) Resume() {
+	b.Spec.Paused = false
+	b.Spec.PauseInfo = nil
+}
+
+// SetPausedCondition reflects the pause state of the BackupConfiguration at the given time in its conditions
+func (b *BackupConfiguration) SetPausedCondition(now time.Time) {
+	b.Status.Conditions = apis.SetPausedCondition(b.Status.Conditions, b.Spec.Paused, b.Spec.PauseInfo, now)
+}
@@ -0,0 +1,445 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	cutil "kmodules.xyz/client-go/conditions"
+)
+
+func TestSchedulerNextSchedule(t *testing.T) {
+	dhaka, err := time.LoadLocation("Asia/Dhaka")
+	assert.Nil(t, err)
+	// Friday, 2024-05-31 08:30 at Asia/Dhaka
+	now := time.Date(2024, time.May, 31, 8, 30, 0, 0, dhaka)
+
+	tests := []struct {
+		name      string
+		scheduler SchedulerSpec
+		expected  time.Time
+	}{
+		{
+			name: "Schedule should be evaluated in the specified time zone",
+			scheduler: SchedulerSpec{
+				Schedule: "0 9 * * *",
+				TimeZone: ptr.To("Asia/Dhaka"),
+			},
+			expected: time.Date(2024, time.May, 31, 9, 0, 0, 0, dhaka),
+		},
+		{
+			name: "Schedule should skip the runs outside of the backup windows",
+			scheduler: SchedulerSpec{
+				Schedule: "0 * * * *",
+				TimeZone: ptr.To("Asia/Dhaka"),
+				Windows: []TimeWindow{
+					{StartTime: "22:00", EndTime: "06:00"},
+				},
+			},
+			expected: time.Date(2024, time.May, 31, 22, 0, 0, 0, dhaka),
+		},
+		{
+			name: "Schedule should skip the recurring blackout periods",
+			scheduler: SchedulerSpec{
+				Schedule: "0 12 * * *",
+				TimeZone: ptr.To("Asia/Dhaka"),
+				Blackouts: []BlackoutPeriod{
+					{
+						Name: "office-hours",
+						Recurring: &TimeWindow{
+							Days:      []Weekday{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+							StartTime: "09:00",
+							EndTime:   "18:00",
+						},
+					},
+				},
+			},
+			expected: time.Date(2024, time.June, 1, 12, 0, 0, 0, dhaka),
+		},
+		{
+			name: "Schedule should skip the month-end freeze and fixed blackout periods",
+			scheduler: SchedulerSpec{
+				Schedule: "0 0 * * *",
+				TimeZone: ptr.To("Asia/Dhaka"),
+				Blackouts: []BlackoutPeriod{
+					{
+						Name:      "month-end-freeze",
+						Recurring: &TimeWindow{DaysOfMonth: []int32{-2, -1}},
+					},
+					{
+						Name:  "maintenance",
+						Start: &metav1.Time{Time: time.Date(2024, time.June, 1, 0, 0, 0, 0, dhaka)},
+						End:   &metav1.Time{Time: time.Date(2024, time.June, 3, 0, 0, 0, 0, dhaka)},
+					},
+				},
+			},
+			expected: time.Date(2024, time.June, 3, 0, 0, 0, 0, dhaka),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next, err := test.scheduler.NextSchedule("", now)
+			assert.Nil(t, err)
+			assert.True(t, test.expected.Equal(next), "expected %s, got %s", test.expected, next)
+		})
+	}
+}
+
+func TestSchedulerEffectiveSchedule(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedule  string
+		expectErr bool
+		verify    func(t *testing.T, fields []string)
+	}{
+		{
+			name:     "Schedule without H should be left as it is",
+			schedule: "*/5 2 * * 1-5",
+			verify: func(t *testing.T, fields []string) {
+				assert.Equal(t, []string{"*/5", "2", "*", "*", "1-5"}, fields)
+			},
+		},
+		{
+			name:     "H should be resolved after the time zone prefix",
+			schedule: "CRON_TZ=Asia/Dhaka H 2 H * *",
+			verify: func(t *testing.T, fields []string) {
+				assert.Equal(t, "CRON_TZ=Asia/Dhaka", fields[0])
+				assertInRange(t, fields[1], 0, 59)
+				assert.Equal(t, "2", fields[2])
+				assertInRange(t, fields[3], 1, 28)
+			},
+		},
+		{
+			name:     "H should be resolved within the range of the field",
+			schedule: "H H(0-5) * * *",
+			verify: func(t *testing.T, fields []string) {
+				assertInRange(t, fields[0], 0, 59)
+				assertInRange(t, fields[1], 0, 5)
+			},
+		},
+		{
+			name:     "H with step should start within the first step",
+			schedule: "H/15 * * * *",
+			verify: func(t *testing.T, fields []string) {
+				start, rest, _ := strings.Cut(fields[0], "-")
+				assertInRange(t, start, 0, 14)
+				assert.Equal(t, "59/15", rest)
+			},
+		},
+		{
+			name:      "H with out of bound range should be rejected",
+			schedule:  "H(30-70) * * * *",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheduler := SchedulerSpec{Schedule: test.schedule}
+			effective, err := scheduler.GetEffectiveSchedule("7b8a1c52-3f1e-4f0a-9d7e-2c6a9f1b0e11")
+			if test.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			test.verify(t, strings.Fields(effective))
+
+			again, _ := scheduler.GetEffectiveSchedule("7b8a1c52-3f1e-4f0a-9d7e-2c6a9f1b0e11")
+			assert.Equal(t, effective, again, "effective schedule should be stable for the same UID")
+		})
+	}
+}
+
+func TestSchedulerSpreadAcrossInvokers(t *testing.T) {
+	scheduler := SchedulerSpec{
+		Schedule: "H 0 * * *",
+		TimeZone: ptr.To("UTC"),
+		Jitter:   &metav1.Duration{Duration: 10 * time.Minute},
+	}
+	now := time.Date(2024, time.May, 31, 12, 0, 0, 0, time.UTC)
+
+	starts := map[time.Time]bool{}
+	for i := 0; i < 20; i++ {
+		uid := types.UID(fmt.Sprintf("uid-%d", i))
+		offset := scheduler.GetJitterOffset(uid)
+		assert.True(t, offset >= 0 && offset < 10*time.Minute)
+
+		next, err := scheduler.NextSchedule(uid, now)
+		assert.Nil(t, err)
+		assert.True(t, next.After(now))
+		assert.True(t, next.Before(now.Add(13*time.Hour)), "next schedule %s should be on the next midnight", next)
+		starts[next] = true
+	}
+	assert.Greater(t, len(starts), 10, "backups should be spread across different start times")
+}
+
+func assertInRange(t *testing.T, value string, lower, upper int) {
+	v, err := strconv.Atoi(value)
+	assert.Nil(t, err)
+	assert.True(t, v >= lower && v <= upper, "%d is not in range %d-%d", v, lower, upper)
+}
+
+func TestSchedulerSkipReason(t *testing.T) {
+	scheduler := SchedulerSpec{
+		Schedule: "0 * * * *",
+		TimeZone: ptr.To("UTC"),
+		Windows:  []TimeWindow{{StartTime: "00:00", EndTime: "06:00"}},
+		Blackouts: []BlackoutPeriod{
+			{Name: "sunday", Recurring: &TimeWindow{Days: []Weekday{"Sunday"}}},
+		},
+	}
+
+	reason, _, err := scheduler.GetSkipReason(time.Date(2024, time.June, 3, 1, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Empty(t, reason)
+
+	reason, _, _ = scheduler.GetSkipReason(time.Date(2024, time.June, 3, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, ReasonSkippedOutsideBackupWindow, reason)
+
+	reason, message, _ := scheduler.GetSkipReason(time.Date(2024, time.June, 2, 1, 0, 0, 0, time.UTC))
+	assert.Equal(t, ReasonSkippedInBlackoutPeriod, reason)
+	assert.Contains(t, message, "sunday")
+
+	bs := &BackupSession{}
+	bs.SetBackupSkippedConditionToTrue(reason, message)
+	assert.Equal(t, BackupSessionSkipped, bs.CalculatePhase())
+}
+
+func TestBackupTriggerDebounceAndRateLimit(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	trigger := BackupTrigger{
+		Name:      "config-change",
+		Type:      BackupTriggerObjectChange,
+		Debounce:  &metav1.Duration{Duration: time.Minute},
+		RateLimit: &TriggerRateLimit{MaxBackups: 2, Period: metav1.Duration{Duration: time.Hour}},
+	}
+	session := SessionStatus{Name: "frequent-backup"}
+	status := session.GetTriggerStatus(trigger.Name)
+
+	fire, _ := trigger.ShouldTrigger(*status, now)
+	assert.False(t, fire, "no backup should be triggered without any event")
+
+	status.RecordEvent(now)
+	fire, wait := trigger.ShouldTrigger(*status, now.Add(20*time.Second))
+	assert.False(t, fire, "backup should be delayed until the events settle")
+	assert.Equal(t, 40*time.Second, wait)
+
+	fire, _ = trigger.ShouldTrigger(*status, now.Add(time.Minute))
+	assert.True(t, fire)
+	trigger.RecordTriggered(status, now.Add(time.Minute))
+
+	status.RecordEvent(now.Add(2 * time.Minute))
+	fire, _ = trigger.ShouldTrigger(*status, now.Add(3*time.Minute))
+	assert.True(t, fire)
+	trigger.RecordTriggered(status, now.Add(3*time.Minute))
+
+	status.RecordEvent(now.Add(4 * time.Minute))
+	fire, wait = trigger.ShouldTrigger(*status, now.Add(5*time.Minute))
+	assert.False(t, fire, "backup should be delayed by the rate limit")
+	assert.Equal(t, 56*time.Minute, wait)
+
+	fire, _ = trigger.ShouldTrigger(*status, now.Add(61*time.Minute))
+	assert.True(t, fire)
+	trigger.RecordTriggered(status, now.Add(61*time.Minute))
+	assert.Len(t, session.Triggers[0].TriggeredTimes, 2)
+}
+
+func TestSessionBackupType(t *testing.T) {
+	// Saturday, 2024-06-01 12:00 UTC
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	session := SessionConfig{
+		Scheduler: &SchedulerSpec{Schedule: "0 * * * *", TimeZone: ptr.To("UTC")},
+		BackupTypePolicy: &BackupTypePolicy{
+			FullBackupSchedule: "0 0 * * 0",
+			MaxIncrementals:    ptr.To(int32(24)),
+		},
+	}
+
+	full := &storageapi.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "full"},
+		Spec:       storageapi.SnapshotSpec{Type: storageapi.BackupTypeFull},
+		Status:     storageapi.SnapshotStatus{SnapshotTime: &metav1.Time{Time: now.Add(-time.Hour)}},
+	}
+	incremental := &storageapi.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "incremental"},
+		Spec:       storageapi.SnapshotSpec{Type: storageapi.BackupTypeIncremental},
+	}
+	assert.Nil(t, incremental.SetChain(full))
+
+	tests := []struct {
+		name     string
+		last     *storageapi.Snapshot
+		now      time.Time
+		expected storageapi.BackupType
+	}{
+		{
+			name:     "Backup should be full if there is no previous snapshot",
+			now:      now,
+			expected: storageapi.BackupTypeFull,
+		},
+		{
+			name:     "Backup should be incremental before the next full backup schedule",
+			last:     incremental,
+			now:      now,
+			expected: storageapi.BackupTypeIncremental,
+		},
+		{
+			name:     "Backup should be full once the full backup schedule elapsed",
+			last:     incremental,
+			now:      time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC),
+			expected: storageapi.BackupTypeFull,
+		},
+		{
+			name: "Backup should be full after the maximum number of incrementals",
+			last: &storageapi.Snapshot{
+				Spec: storageapi.SnapshotSpec{
+					Type:  storageapi.BackupTypeIncremental,
+					Chain: &storageapi.SnapshotChain{BaseSnapshot: "full", BaseSnapshotTime: full.Status.SnapshotTime, Length: 24},
+				},
+			},
+			now:      now,
+			expected: storageapi.BackupTypeFull,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backupType, err := session.GetBackupType(test.last, test.now)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, backupType)
+		})
+	}
+}
+
+func TestBackupConfigurationRPOCompliance(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	bc := &BackupConfiguration{
+		Spec: BackupConfigurationSpec{
+			Sessions: []Session{
+				{
+					SessionConfig: &SessionConfig{
+						Name: "frequent-backup",
+						RPO:  &metav1.Duration{Duration: 6 * time.Hour},
+					},
+					Repositories: []RepositoryInfo{{Name: "s3-repo"}},
+				},
+			},
+		},
+	}
+
+	violated, err := bc.UpdateRPOCompliance("frequent-backup", nil, nil, now)
+	assert.Nil(t, err)
+	assert.True(t, violated, "RPO should be violated without any successful backup")
+
+	repositories := []storageapi.Repository{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "s3-repo"},
+			Status:     storageapi.RepositoryStatus{LastBackupTime: &metav1.Time{Time: now.Add(-8 * time.Hour)}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-repo"},
+			Status:     storageapi.RepositoryStatus{LastBackupTime: &metav1.Time{Time: now}},
+		},
+	}
+	violated, _ = bc.UpdateRPOCompliance("frequent-backup", repositories, nil, now)
+	assert.True(t, violated, "RPO should be violated if the latest backup is too old")
+
+	snapshots := []storageapi.Snapshot{
+		{
+			Spec: storageapi.SnapshotSpec{Session: "frequent-backup", Repository: "s3-repo"},
+			Status: storageapi.SnapshotStatus{
+				Phase:        storageapi.SnapshotSucceeded,
+				SnapshotTime: &metav1.Time{Time: now.Add(-time.Hour)},
+			},
+		},
+	}
+	violated, _ = bc.UpdateRPOCompliance("frequent-backup", repositories, snapshots, now)
+	assert.False(t, violated)
+	assert.True(t, cutil.IsConditionFalse(bc.Status.Conditions, TypeRPOViolated))
+
+	status := bc.GetSessionStatus("frequent-backup")
+	status.RecordBackupResult(BackupSessionFailed, now)
+	status.RecordBackupResult(BackupSessionFailed, now.Add(time.Hour))
+	assert.Equal(t, int32(2), status.ConsecutiveFailures)
+	status.RecordBackupResult(BackupSessionPartiallySucceeded, now.Add(2*time.Hour))
+	assert.Equal(t, int32(0), status.ConsecutiveFailures)
+	assert.Equal(t, now.Add(2*time.Hour), status.LastSuccessTime.Time)
+}
+
+func TestBackupConfigurationPause(t *testing.T) {
+	now := time.Date(2024, time.May, 31, 12, 0, 0, 0, time.UTC)
+	resumeAt := metav1.NewTime(now.Add(time.Hour))
+
+	bc := &BackupConfiguration{
+		Spec: BackupConfigurationSpec{
+			Paused: true,
+			PauseInfo: &apis.PauseInfo{
+				Reason:   "maintenance",
+				PausedBy: "admin",
+				ResumeAt: &resumeAt,
+				Sessions: []string{"hourly"},
+			},
+		},
+	}
+
+	assert.False(t, bc.IsPaused(now), "only the listed sessions should be paused")
+	assert.True(t, bc.IsSessionPaused("hourly", now))
+	assert.False(t, bc.IsSessionPaused("daily", now))
+	assert.False(t, bc.IsSessionPaused("hourly", resumeAt.Time), "pause should expire at the resume time")
+
+	bc.SetPausedCondition(now)
+	_, cond := cutil.GetCondition(bc.Status.Conditions, apis.TypePaused)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, apis.ReasonSessionsPaused, cond.Reason)
+		assert.Contains(t, cond.Message, "admin")
+		assert.Contains(t, cond.Message, "maintenance")
+	}
+
+	// a restore pauses the whole BackupConfiguration and restores the original state afterward
+	original := bc.Pause(apis.PauseInfo{Reason: "restore in progress"})
+	assert.True(t, bc.IsPaused(now))
+	assert.True(t, bc.IsSessionPaused("daily", now))
+
+	bc.RestorePauseState(original)
+	assert.False(t, bc.IsPaused(now))
+	assert.True(t, bc.IsSessionPaused("hourly", now))
+	assert.Equal(t, "maintenance", bc.Spec.PauseInfo.Reason)
+
+	bc.Resume()
+	assert.False(t, bc.IsSessionPaused("hourly", now))
+	bc.SetPausedCondition(now)
+	assert.False(t, cutil.HasCondition(bc.Status.Conditions, apis.TypePaused))
+}
+
+func TestSchedulerHasHashedFields(t *testing.T) {
+	assert.True(t, (&SchedulerSpec{Schedule: "H 2 * * *"}).HasHashedFields())
+	assert.True(t, (&SchedulerSpec{Schedule: "TZ=UTC 0,H(30-59) 2 * * *"}).HasHashedFields())
+	assert.False(t, (&SchedulerSpec{Schedule: "CRON_TZ=Asia/Dhaka */5 2 * * 1-5"}).HasHashedFields())
+	assert.False(t, (&SchedulerSpec{Schedule: "@daily"}).HasHashedFields())
+}
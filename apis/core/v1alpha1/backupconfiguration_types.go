@@ -17,9 +17,11 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/apis/storage/v1alpha1"
 
 	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kmapi "kmodules.xyz/client-go/api/v1"
 	ofst "kmodules.xyz/offshoot-api/api/v1"
@@ -72,6 +74,12 @@ type BackupConfigurationSpec struct {
 	// skip processing any further events for this BackupConfiguration.
 	// +optional
 	Paused bool `json:"paused,omitempty"`
+
+	// PauseInfo specifies the details of the pause, i.e. why and by whom the BackupConfiguration was paused and when it should be resumed.
+	// It is only effective when `paused` is set to `true`.
+	// If `sessions` is specified, only those sessions are paused.
+	// +optional
+	PauseInfo *apis.PauseInfo `json:"pauseInfo,omitempty"`
 }
 
 // BackendReference specifies reference to a storage where the backed up data will be stored.
@@ -112,6 +120,25 @@ type SessionConfig struct {
 	// Scheduler specifies the configuration for backup triggering CronJob
 	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
 
+	// Triggers specifies the events that should trigger a backup in addition to the schedule.
+	// +optional
+	Triggers []BackupTrigger `json:"triggers,omitempty"`
+
+	// RPO specifies the recovery point objective of the session, i.e. the maximum age of the latest successful backup.
+	// The "RPOViolated" condition is set in the session status when there is no successful backup within this duration.
+	// +optional
+	RPO *metav1.Duration `json:"rpo,omitempty"`
+
+	// SuccessPolicy specifies which of the Snapshots taken in the Repositories of the session must succeed
+	// for the backup to be considered successful. If not specified, all the Snapshots must succeed.
+	// +optional
+	SuccessPolicy *BackupSuccessPolicy `json:"successPolicy,omitempty"`
+
+	// BackupTypePolicy specifies when a full backup should be taken instead of an incremental one.
+	// If not specified, every backup is a full backup.
+	// +optional
+	BackupTypePolicy *BackupTypePolicy `json:"backupTypePolicy,omitempty"`
+
 	// Hooks specifies the backup hooks that should be executed before and/or after the backup.
 	// +optional
 	Hooks *BackupHooks `json:"hooks,omitempty"`
@@ -142,8 +169,36 @@ type SessionConfig struct {
 // SchedulerSpec specifies the configuration for the backup triggering CronJob for a session.
 type SchedulerSpec struct {
 	// The schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
+	// Similar to Jenkins, a field can be "H" to spread the backups of the invokers sharing the same schedule.
+	// "H" is replaced by a stable value derived from the UID of the invoker within the range of the field,
+	// i.e. "H H(0-5) * * *" runs the backup once a day at a stable time between 00:00 and 05:59.
+	// The supported forms are "H", "H(<min>-<max>)", "H/<step>" and "H(<min>-<max>)/<step>".
+	// For the day of month, "H" is limited to 1-28 so that the resolved day exists in every month.
+	// The "H" fields are supported only for the sessions of a BackupConfiguration.
 	Schedule string `json:"schedule"`
 
+	// Jitter specifies a window within which the backup is delayed from the scheduled time.
+	// Each invoker is delayed by a stable offset derived from its UID within this window.
+	// +optional
+	Jitter *metav1.Duration `json:"jitter,omitempty"`
+
+	// TimeZone specifies the time zone name for the given schedule, see https://en.wikipedia.org/wiki/List_of_tz_database_time_zones.
+	// The backup windows and blackout periods are also evaluated in this time zone.
+	// If not specified, the time zone of the KubeStash operator will be used.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// Windows specifies the time windows when the backups are allowed to run.
+	// The scheduled backups that fall outside of all the windows are skipped.
+	// If not specified, the backups are allowed to run at any time.
+	// +optional
+	Windows []TimeWindow `json:"windows,omitempty"`
+
+	// Blackouts specifies the periods when the backups must not run.
+	// The scheduled backups that fall inside any of these periods are skipped.
+	// +optional
+	Blackouts []BlackoutPeriod `json:"blackouts,omitempty"`
+
 	// Optional deadline in seconds for starting the job if it misses scheduled
 	// time for any reason.  Missed jobs executions will be counted as failed ones.
 	// +optional
@@ -176,6 +231,191 @@ type SchedulerSpec struct {
 	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
 }
 
+// BackupSuccessPolicy specifies how many Snapshots of a BackupSession must succeed for the backup to be considered successful.
+// When the policy is satisfied but some Snapshots failed, the BackupSession is marked as "PartiallySucceeded".
+type BackupSuccessPolicy struct {
+	// Type specifies the kind of the policy.
+	// The valid values are:
+	// - "AllRequired": All the Snapshots must succeed. This is the default behavior.
+	// - "AnyOf": At least one Snapshot must succeed.
+	// - "Quorum": At least the number of Snapshots specified in the `quorum` field must succeed.
+	// +kubebuilder:default=AllRequired
+	// +optional
+	Type BackupSuccessPolicyType `json:"type,omitempty"`
+
+	// Quorum specifies the minimum number of Snapshots that must succeed for "Quorum" policy
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Quorum *int32 `json:"quorum,omitempty"`
+
+	// RequiredRepositories specifies the Repositories whose Snapshots must succeed regardless of the policy type
+	// +optional
+	RequiredRepositories []string `json:"requiredRepositories,omitempty"`
+}
+
+// BackupSuccessPolicyType specifies the kind of the backup success policy
+// +kubebuilder:validation:Enum=AllRequired;AnyOf;Quorum
+type BackupSuccessPolicyType string
+
+const (
+	BackupSuccessPolicyAllRequired BackupSuccessPolicyType = "AllRequired"
+	BackupSuccessPolicyAnyOf       BackupSuccessPolicyType = "AnyOf"
+	BackupSuccessPolicyQuorum      BackupSuccessPolicyType = "Quorum"
+)
+
+// BackupTypePolicy specifies the cadence of the full and incremental backups of a session.
+// A backup is taken as full if there is no previous Snapshot to build on, the full backup schedule
+// has elapsed since the last full backup or the chain has reached the maximum number of incrementals.
+// Otherwise, the backup is taken as incremental.
+type BackupTypePolicy struct {
+	// FullBackupSchedule specifies when the full backups should be taken in Cron format, i.e. "0 0 * * 0" for every Sunday.
+	// It is evaluated in the time zone of the scheduler.
+	// +optional
+	FullBackupSchedule string `json:"fullBackupSchedule,omitempty"`
+
+	// MaxIncrementals specifies the maximum number of incremental backups after which a full backup is forced
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxIncrementals *int32 `json:"maxIncrementals,omitempty"`
+}
+
+// BackupTrigger specifies an event that should trigger a backup
+type BackupTrigger struct {
+	// Name specifies the name of the trigger. It must be unique within the session.
+	Name string `json:"name"`
+
+	// Type specifies the type of the event that triggers the backup.
+	// The valid values are:
+	// - "DatabaseUpgrade": The backup is triggered before the version of the target KubeDB database is upgraded.
+	// - "VolumeResize": The backup is triggered when a PVC of the target is resized.
+	// - "ObjectChange": The backup is triggered when any of the watched ConfigMaps or Secrets changes.
+	// - "Webhook": The backup is triggered by a call to the KubeStash webhook endpoint.
+	// - "BackupSessionSucceeded": The backup is triggered after a BackupSession of another session succeeds.
+	Type BackupTriggerType `json:"type"`
+
+	// ObjectChange specifies the objects to watch for "ObjectChange" trigger
+	// +optional
+	ObjectChange *ObjectChangeTrigger `json:"objectChange,omitempty"`
+
+	// Webhook specifies the configuration for "Webhook" trigger
+	// +optional
+	Webhook *WebhookTrigger `json:"webhook,omitempty"`
+
+	// BackupSessionSucceeded specifies the session to follow for "BackupSessionSucceeded" trigger
+	// +optional
+	BackupSessionSucceeded *SessionChainTrigger `json:"backupSessionSucceeded,omitempty"`
+
+	// Debounce specifies how long to wait for the events to settle before triggering a backup.
+	// The events that happen within this duration of the previous one are merged into a single backup.
+	// +optional
+	Debounce *metav1.Duration `json:"debounce,omitempty"`
+
+	// RateLimit specifies the maximum number of backups this trigger can create within a period
+	// +optional
+	RateLimit *TriggerRateLimit `json:"rateLimit,omitempty"`
+}
+
+// BackupTriggerType specifies the type of the event that triggers a backup
+// +kubebuilder:validation:Enum=DatabaseUpgrade;VolumeResize;ObjectChange;Webhook;BackupSessionSucceeded
+type BackupTriggerType string
+
+const (
+	BackupTriggerDatabaseUpgrade        BackupTriggerType = "DatabaseUpgrade"
+	BackupTriggerVolumeResize           BackupTriggerType = "VolumeResize"
+	BackupTriggerObjectChange           BackupTriggerType = "ObjectChange"
+	BackupTriggerWebhook                BackupTriggerType = "Webhook"
+	BackupTriggerBackupSessionSucceeded BackupTriggerType = "BackupSessionSucceeded"
+)
+
+// ObjectChangeTrigger specifies the ConfigMaps and Secrets to watch for changes
+type ObjectChangeTrigger struct {
+	// ConfigMaps specifies the names of the ConfigMaps to watch in the namespace of the invoker
+	// +optional
+	ConfigMaps []string `json:"configMaps,omitempty"`
+
+	// Secrets specifies the names of the Secrets to watch in the namespace of the invoker
+	// +optional
+	Secrets []string `json:"secrets,omitempty"`
+}
+
+// WebhookTrigger specifies the configuration for triggering a backup through the webhook endpoint
+type WebhookTrigger struct {
+	// SecretRef refers to the Secret in the namespace of the invoker holding the token the caller must present.
+	// The token must be stored under the "token" key.
+	SecretRef *core.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// SessionChainTrigger specifies the session whose successful backup triggers this session
+type SessionChainTrigger struct {
+	// Invoker refers to the BackupConfiguration or BackupBatch of the session to follow.
+	// If not specified, the invoker of this session is used.
+	// +optional
+	Invoker *core.TypedLocalObjectReference `json:"invoker,omitempty"`
+
+	// Session specifies the name of the session to follow
+	Session string `json:"session"`
+}
+
+// TriggerRateLimit specifies the maximum number of backups a trigger can create within a period
+type TriggerRateLimit struct {
+	// MaxBackups specifies the maximum number of backups within the period
+	// +kubebuilder:validation:Minimum=1
+	MaxBackups int32 `json:"maxBackups"`
+
+	// Period specifies the length of the sliding window the backups are counted in
+	Period metav1.Duration `json:"period"`
+}
+
+// TimeWindow specifies a recurring period of the day.
+type TimeWindow struct {
+	// Days specifies the days of the week this window applies to.
+	// If not specified, the window applies to every day of the week.
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+
+	// DaysOfMonth specifies the days of the month this window applies to.
+	// The negative values count from the end of the month, i.e. -1 refers to the last day of the month.
+	// If not specified, the window applies to every day of the month.
+	// +optional
+	DaysOfMonth []int32 `json:"daysOfMonth,omitempty"`
+
+	// StartTime specifies the start of the window in "HH:MM" format.
+	// If not specified, the window starts at the beginning of the day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+
+	// EndTime specifies the end of the window in "HH:MM" format.
+	// If it is not after the StartTime, the window ends at that time on the following day.
+	// If not specified, the window ends at the end of the day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// +optional
+	EndTime string `json:"endTime,omitempty"`
+}
+
+// Weekday specifies a day of the week
+// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+type Weekday string
+
+// BlackoutPeriod specifies a period when the backups must not run.
+// Either a fixed period using Start and End or a recurring period using Recurring must be specified.
+type BlackoutPeriod struct {
+	// Name specifies the name of the blackout period. It is reported when a backup is skipped.
+	Name string `json:"name"`
+
+	// Start specifies the beginning of a fixed blackout period
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// End specifies the end of a fixed blackout period
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+
+	// Recurring specifies a blackout period that repeats according to the time window
+	// +optional
+	Recurring *TimeWindow `json:"recurring,omitempty"`
+}
+
 // JobTemplate specifies the template for the Job created by the scheduler CronJob.
 type JobTemplate struct {
 	// Specifies the maximum desired number of pods the job should
@@ -417,11 +657,49 @@ type SessionStatus struct {
 	// +optional
 	NextSchedule string `json:"nextSchedule,omitempty"`
 
+	// EffectiveSchedule specifies the cron schedule of this session after resolving the "H" fields
+	// +optional
+	EffectiveSchedule string `json:"effectiveSchedule,omitempty"`
+
+	// Triggers specifies the status of the event triggers of this session
+	// +optional
+	Triggers []TriggerStatus `json:"triggers,omitempty"`
+
+	// LastSuccessTime specifies when the last successful backup of this session was taken
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+
+	// LastFailureTime specifies when the last backup of this session failed
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// ConsecutiveFailures specifies the number of backups of this session that failed since the last successful one
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
 	// Conditions specifies a list of conditions related to this session
 	// +optional
 	Conditions []kmapi.Condition `json:"conditions,omitempty"`
 }
 
+// TriggerStatus specifies the status of an event trigger
+type TriggerStatus struct {
+	// Name indicates the name of the trigger
+	Name string `json:"name"`
+
+	// LastEventTime specifies when the last event was observed for this trigger
+	// +optional
+	LastEventTime *metav1.Time `json:"lastEventTime,omitempty"`
+
+	// PendingEvent specifies whether an observed event has not triggered a backup yet
+	// +optional
+	PendingEvent bool `json:"pendingEvent,omitempty"`
+
+	// TriggeredTimes specifies when the recent backups were triggered. It is used to enforce the rate limit.
+	// +optional
+	TriggeredTimes []metav1.Time `json:"triggeredTimes,omitempty"`
+}
+
 const (
 	// TypeValidationPassed indicates the validation conditions of the CRD are passed or not.
 	TypeValidationPassed           = "ValidationPassed"
@@ -433,6 +711,11 @@ const (
 	ReasonSchedulerNotEnsured = "SchedulerNotEnsured"
 	ReasonSchedulerEnsured    = "SchedulerEnsured"
 
+	// TypeRPOViolated indicates whether the latest successful backup is older than the recovery point objective or not.
+	TypeRPOViolated    = "RPOViolated"
+	ReasonRPOViolated  = "RecoveryPointObjectiveViolated"
+	ReasonRPOSatisfied = "RecoveryPointObjectiveSatisfied"
+
 	// TypeInitialBackupTriggered indicates whether the initial backup is triggered or not.
 	TypeInitialBackupTriggered               = "InitialBackupTriggered"
 	ReasonFailedToTriggerInitialBackup       = "FailedToTriggerInitialBackup"
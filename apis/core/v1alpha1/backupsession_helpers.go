@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"kubestash.dev/apimachinery/apis"
@@ -43,6 +44,7 @@ func (b *BackupSession) IsCompleted() bool {
 	phase := b.Status.Phase
 
 	return phase == BackupSessionSucceeded ||
+		phase == BackupSessionPartiallySucceeded ||
 		phase == BackupSessionFailed ||
 		phase == BackupSessionSkipped
 }
@@ -61,6 +63,7 @@ func (b *BackupSession) CalculatePhase() BackupSessionPhase {
 			b.failedToEnsureSnapshots() ||
 			b.failedToExecutePreBackupHooks() ||
 			b.failedToExecutePostBackupHooks() ||
+			b.Status.Hooks.HasUndoHookInPhase(HookExecutionFailed) ||
 			b.failedToApplyRetentionPolicy() ||
 			b.sessionHistoryCleanupFailed() ||
 			b.snapshotCleanupIncomplete()) {
@@ -68,13 +71,28 @@ func (b *BackupSession) CalculatePhase() BackupSessionPhase {
 	}
 
 	componentsPhase := b.calculateBackupSessionPhaseFromSnapshots()
-	if componentsPhase == BackupSessionPending || b.FinalStepExecuted() {
+	if componentsPhase != BackupSessionPending && b.Status.Hooks.HasUndoHookInPhase(HookExecutionPending) {
+		// the session is not completed, and hence the final step is not executed, until the undo hooks are delivered
+		return BackupSessionRunning
+	}
+	if b.FinalStepExecuted() {
+		return componentsPhase
+	}
+	// a queued session is reported as such only if it has neither failed nor completed
+	if b.IsQueued() {
+		return BackupSessionQueued
+	}
+	if componentsPhase == BackupSessionPending {
 		return componentsPhase
 	}
 
 	return BackupSessionRunning
 }
 
+func (b *BackupSession) IsQueued() bool {
+	return cutil.IsConditionTrue(b.Status.Conditions, TypeBackupQueued)
+}
+
 func (b *BackupSession) snapshotCleanupIncomplete() bool {
 	return cutil.IsConditionTrue(b.Status.Conditions, TypeSnapshotCleanupIncomplete)
 }
@@ -143,11 +161,47 @@ func (b *BackupSession) calculateBackupSessionPhaseFromSnapshots() BackupSession
 		return BackupSessionRunning
 	}
 
-	if failed > 0 {
-		return BackupSessionFailed
+	if failed == 0 {
+		return BackupSessionSucceeded
+	}
+
+	if b.Spec.SuccessPolicy.IsSatisfied(status) {
+		return BackupSessionPartiallySucceeded
+	}
+
+	return BackupSessionFailed
+}
+
+// IsSatisfied returns whether the completed Snapshots satisfy the success policy.
+// A nil policy requires all the Snapshots to succeed.
+func (p *BackupSuccessPolicy) IsSatisfied(snapshots []SnapshotStatus) bool {
+	succeeded := 0
+	succeededRepos := make(map[string]bool)
+	for _, s := range snapshots {
+		if s.Phase == storageapi.SnapshotSucceeded {
+			succeeded++
+			succeededRepos[s.Repository] = true
+		}
+	}
+
+	if p == nil {
+		return succeeded == len(snapshots)
+	}
+
+	for _, repo := range p.RequiredRepositories {
+		if !succeededRepos[repo] {
+			return false
+		}
 	}
 
-	return BackupSessionSucceeded
+	switch p.Type {
+	case BackupSuccessPolicyAnyOf:
+		return succeeded > 0
+	case BackupSuccessPolicyQuorum:
+		return p.Quorum != nil && int32(succeeded) >= *p.Quorum
+	default:
+		return succeeded == len(snapshots)
+	}
 }
 
 func GenerateBackupSessionName(invokerName, sessionName string) string {
@@ -169,6 +223,9 @@ func (b *BackupSession) GetSummary(targetRef *kmapi.TypedObjectReference) *Summa
 	phase := BackupSessionSucceeded
 	if errMsg != "" {
 		phase = BackupSessionFailed
+	} else if failed := b.getFailedSnapshots(); len(failed) > 0 {
+		phase = BackupSessionPartiallySucceeded
+		errMsg = fmt.Sprintf("snapshots %s are failed", strings.Join(failed, ", "))
 	}
 
 	return &Summary{
@@ -182,7 +239,8 @@ func (b *BackupSession) GetSummary(targetRef *kmapi.TypedObjectReference) *Summa
 			Namespace: b.Namespace,
 		},
 
-		Target: targetRef,
+		Target:        targetRef,
+		TriggerSource: b.Spec.TriggerSource,
 
 		Status: TargetStatus{
 			Phase:    string(phase),
@@ -220,12 +278,20 @@ func (b *BackupSession) checkFailureInConditions() (bool, string) {
 }
 
 func (b *BackupSession) checkFailureInSnapshots() (bool, string) {
+	if len(b.getFailedSnapshots()) > 0 && !b.Spec.SuccessPolicy.IsSatisfied(b.Status.Snapshots) {
+		return true, "one or more snapshots are failed"
+	}
+	return false, ""
+}
+
+func (b *BackupSession) getFailedSnapshots() []string {
+	var failed []string
 	for _, snapStatus := range b.Status.Snapshots {
 		if snapStatus.Phase == storageapi.SnapshotFailed {
-			return true, "one or more snapshots are failed"
+			failed = append(failed, snapStatus.Name)
 		}
 	}
-	return false, ""
+	return failed
 }
 
 func (b *BackupSession) checkFailureInRetentionPolicy() (bool, string) {
@@ -247,3 +313,47 @@ func (b *BackupSession) GetRemainingTimeoutDuration() (*metav1.Duration, error)
 	}
 	return &metav1.Duration{Duration: b.Status.BackupDeadline.Sub(currentTime.Time)}, nil
 }
+
+// SetDuration sets both the human-readable and the numeric duration of the backup process.
+func (b *BackupSession) SetDuration(d time.Duration) {
+	b.Status.Duration = d.String()
+	b.Status.DurationSeconds = int64(d.Seconds())
+}
+
+// SetBackupSkippedConditionToTrue marks the BackupSession as skipped with the given reason.
+func (b *BackupSession) SetBackupSkippedConditionToTrue(reason, message string) {
+	newCond := kmapi.Condition{
+		Type:    TypeBackupSkipped,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	}
+	b.Status.Conditions = cutil.SetCondition(b.Status.Conditions, newCond)
+}
+
+// SetBackupQueuedConditionToTrue marks the BackupSession as waiting for a concurrency slot at the given position of the queue.
+func (b *BackupSession) SetBackupQueuedConditionToTrue(position int32, reason, message string) {
+	enqueueTime := metav1.Now()
+	if b.Status.Queue != nil && b.Status.Queue.EnqueueTime != nil {
+		enqueueTime = *b.Status.Queue.EnqueueTime
+	}
+	b.Status.Queue = &QueueStatus{
+		Position:    position,
+		Reason:      reason,
+		EnqueueTime: &enqueueTime,
+	}
+
+	newCond := kmapi.Condition{
+		Type:    TypeBackupQueued,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	}
+	b.Status.Conditions = cutil.SetCondition(b.Status.Conditions, newCond)
+}
+
+// RemoveBackupQueuedCondition removes the BackupSession from the queue so that it can start running.
+func (b *BackupSession) RemoveBackupQueuedCondition() {
+	b.Status.Queue = nil
+	b.Status.Conditions = cutil.RemoveCondition(b.Status.Conditions, TypeBackupQueued)
+}
@@ -17,13 +17,16 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/apis/storage/v1alpha1"
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	kmapi "kmodules.xyz/client-go/api/v1"
 	cutil "kmodules.xyz/client-go/conditions"
 )
@@ -267,6 +270,31 @@ func TestBackupSessionPhaseSkippedIfSkippedConditionTrue(t *testing.T) {
 	assert.Equal(t, BackupSessionSkipped, bs.CalculatePhase())
 }
 
+func TestBackupSessionPhaseFailedIfQueuedSessionFailed(t *testing.T) {
+	queued := kmapi.Condition{
+		Type:   TypeBackupQueued,
+		Status: metav1.ConditionTrue,
+		Reason: ReasonBackupStorageConcurrencyLimitReached,
+	}
+
+	bs := getSampleBackupSession(func(b *BackupSession) {
+		b.Status.Conditions = cutil.SetCondition(b.Status.Conditions, queued)
+	})
+	assert.Equal(t, BackupSessionQueued, bs.CalculatePhase())
+
+	bs.Status.Conditions = cutil.SetCondition(bs.Status.Conditions, kmapi.Condition{
+		Type:   TypeSnapshotsEnsured,
+		Status: metav1.ConditionFalse,
+		Reason: ReasonFailedToEnsureSnapshots,
+	})
+	bs.Status.Conditions = cutil.SetCondition(bs.Status.Conditions, kmapi.Condition{
+		Type:   TypeMetricsPushed,
+		Status: metav1.ConditionTrue,
+		Reason: ReasonSuccessfullyPushedMetrics,
+	})
+	assert.Equal(t, BackupSessionFailed, bs.CalculatePhase())
+}
+
 func TestBackupSessionPhaseFailedIfSessionHistoryCleanupFailed(t *testing.T) {
 	cond := kmapi.Condition{
 		Type:   TypeSessionHistoryCleaned,
@@ -354,6 +382,81 @@ func TestBackupSessionPhaseSucceededIfAllCriteriaSatisfied(t *testing.T) {
 	assert.Equal(t, BackupSessionSucceeded, bs.CalculatePhase())
 }
 
+func TestBackupSessionPhaseBasedOnSuccessPolicy(t *testing.T) {
+	finalStep := kmapi.Condition{
+		Type:   TypeMetricsPushed,
+		Status: metav1.ConditionTrue,
+		Reason: ReasonSuccessfullyPushedMetrics,
+	}
+	snapshots := []SnapshotStatus{
+		{
+			Name:       "primary",
+			Phase:      v1alpha1.SnapshotSucceeded,
+			Repository: "s3-repo",
+		},
+		{
+			Name:       "secondary",
+			Phase:      v1alpha1.SnapshotSucceeded,
+			Repository: "gcs-repo",
+		},
+		{
+			Name:       "offsite",
+			Phase:      v1alpha1.SnapshotFailed,
+			Repository: "azure-repo",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		policy        *BackupSuccessPolicy
+		expectedPhase BackupSessionPhase
+	}{
+		{
+			name:          "BackupSession should be Failed if no policy is specified",
+			expectedPhase: BackupSessionFailed,
+		},
+		{
+			name:          "BackupSession should be Failed if all Snapshots are required",
+			policy:        &BackupSuccessPolicy{Type: BackupSuccessPolicyAllRequired},
+			expectedPhase: BackupSessionFailed,
+		},
+		{
+			name:          "BackupSession should be PartiallySucceeded if any Snapshot succeeded",
+			policy:        &BackupSuccessPolicy{Type: BackupSuccessPolicyAnyOf},
+			expectedPhase: BackupSessionPartiallySucceeded,
+		},
+		{
+			name:          "BackupSession should be PartiallySucceeded if quorum is reached",
+			policy:        &BackupSuccessPolicy{Type: BackupSuccessPolicyQuorum, Quorum: ptr.To(int32(2))},
+			expectedPhase: BackupSessionPartiallySucceeded,
+		},
+		{
+			name:          "BackupSession should be Failed if quorum is not reached",
+			policy:        &BackupSuccessPolicy{Type: BackupSuccessPolicyQuorum, Quorum: ptr.To(int32(3))},
+			expectedPhase: BackupSessionFailed,
+		},
+		{
+			name: "BackupSession should be Failed if a required Repository failed",
+			policy: &BackupSuccessPolicy{
+				Type:                 BackupSuccessPolicyAnyOf,
+				RequiredRepositories: []string{"s3-repo", "azure-repo"},
+			},
+			expectedPhase: BackupSessionFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bs := getSampleBackupSession(func(b *BackupSession) {
+				b.Spec.SuccessPolicy = test.policy
+				b.Status.Snapshots = snapshots
+				b.Status.Conditions = cutil.SetCondition(b.Status.Conditions, finalStep)
+			})
+			assert.Equal(t, test.expectedPhase, bs.CalculatePhase())
+		})
+	}
+}
+
 func getSampleBackupSession(transformFuncs ...func(configuration *BackupSession)) *BackupSession {
 	bs := &BackupSession{
 		ObjectMeta: metav1.ObjectMeta{
@@ -386,3 +489,103 @@ func getSampleBackupSession(transformFuncs ...func(configuration *BackupSession)
 
 	return bs
 }
+
+func TestBackupSessionPhaseBasedOnUndoHooks(t *testing.T) {
+	finalStep := kmapi.Condition{
+		Type:   TypeMetricsPushed,
+		Status: metav1.ConditionTrue,
+		Reason: ReasonSuccessfullyPushedMetrics,
+	}
+	snapshots := []SnapshotStatus{
+		{
+			Name:       "sample-snapshot",
+			Phase:      v1alpha1.SnapshotSucceeded,
+			Repository: "gcs-repo",
+		},
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		deliver       func(hooks *HookStatus)
+		expectedPhase BackupSessionPhase
+	}{
+		{
+			name:          "BackupSession should be Running if an undo hook has not been delivered yet",
+			deliver:       func(hooks *HookStatus) {},
+			expectedPhase: BackupSessionRunning,
+		},
+		{
+			name: "BackupSession should be Running if an undo hook failed but can be retried",
+			deliver: func(hooks *HookStatus) {
+				hooks.RecordUndoHookAttempt("unfreeze", errors.New("connection refused"), now)
+			},
+			expectedPhase: BackupSessionRunning,
+		},
+		{
+			name: "BackupSession should be Failed if an undo hook failed in all the attempts",
+			deliver: func(hooks *HookStatus) {
+				for range apis.MaxUndoHookAttempts {
+					hooks.RecordUndoHookAttempt("unfreeze", errors.New("connection refused"), now)
+				}
+			},
+			expectedPhase: BackupSessionFailed,
+		},
+		{
+			name: "BackupSession should be Succeeded if the undo hook succeeded on retry",
+			deliver: func(hooks *HookStatus) {
+				hooks.RecordUndoHookAttempt("unfreeze", errors.New("connection refused"), now)
+				hooks.RecordUndoHookAttempt("unfreeze", nil, now)
+			},
+			expectedPhase: BackupSessionSucceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bs := getSampleBackupSession(func(b *BackupSession) {
+				b.Status.Snapshots = snapshots
+				b.Status.Conditions = cutil.SetCondition(b.Status.Conditions, finalStep)
+				b.Status.Hooks.ArmUndoHook("freeze", "unfreeze")
+				test.deliver(&b.Status.Hooks)
+			})
+
+			assert.Equal(t, test.expectedPhase, bs.CalculatePhase())
+		})
+	}
+
+	bs := getSampleBackupSession(func(b *BackupSession) {
+		b.Status.Snapshots = snapshots
+		b.Status.Hooks.ArmUndoHook("freeze", "unfreeze")
+	})
+	assert.Equal(t, BackupSessionRunning, bs.CalculatePhase(), "the final step should wait for the undo hooks to be delivered")
+}
+
+func TestUndoHookDelivery(t *testing.T) {
+	hooks := HookStatus{}
+	hooks.ArmUndoHook("freeze", "unfreeze")
+	hooks.ArmUndoHook("freeze", "unfreeze")
+	hooks.ArmUndoHook("lock", "unfreeze")
+	assert.Len(t, hooks.UndoHooks, 2)
+	assert.Equal(t, []string{"unfreeze"}, hooks.GetUndeliveredUndoHooks())
+
+	hooks.RecordUndoHookAttempt("unfreeze", errors.New("timeout"), time.Now())
+	assert.Equal(t, []string{"unfreeze"}, hooks.GetUndeliveredUndoHooks())
+	assert.Equal(t, "timeout", hooks.UndoHooks[0].Error)
+	assert.Equal(t, HookExecutionPending, hooks.UndoHooks[0].Phase)
+
+	hooks.RecordUndoHookAttempt("unfreeze", nil, time.Now())
+	hooks.RecordUndoHookAttempt("unfreeze", errors.New("ignored"), time.Now())
+	assert.Empty(t, hooks.GetUndeliveredUndoHooks())
+	assert.Equal(t, int32(2), hooks.UndoHooks[0].Attempts)
+	assert.Empty(t, hooks.UndoHooks[0].Error)
+
+	hooks = HookStatus{}
+	hooks.ArmUndoHook("freeze", "unfreeze")
+	for range apis.MaxUndoHookAttempts + 1 {
+		hooks.RecordUndoHookAttempt("unfreeze", errors.New("timeout"), time.Now())
+	}
+	assert.Empty(t, hooks.GetUndeliveredUndoHooks())
+	assert.Equal(t, HookExecutionFailed, hooks.UndoHooks[0].Phase)
+	assert.Equal(t, int32(apis.MaxUndoHookAttempts), hooks.UndoHooks[0].Attempts)
+}
@@ -68,6 +68,39 @@ type BackupSessionSpec struct {
 	// if backup tasks do not complete within this time limit. By default, KubeStash don't set any timeout for backup.
 	// +optional
 	BackupTimeout *metav1.Duration `json:"backupTimeout,omitempty"`
+
+	// SuccessPolicy specifies which of the Snapshots of this session must succeed for the backup to be considered successful.
+	// It is copied from the respective session when the BackupSession is created.
+	// +optional
+	SuccessPolicy *BackupSuccessPolicy `json:"successPolicy,omitempty"`
+
+	// BackupType specifies whether this session takes a full or incremental backup.
+	// It is chosen from the backup type policy of the session when the BackupSession is created.
+	// +optional
+	BackupType storage.BackupType `json:"backupType,omitempty"`
+
+	// TriggerSource specifies the event that triggered this backup.
+	// It is empty for the backups triggered by the scheduler or manually.
+	// +optional
+	TriggerSource *TriggerSource `json:"triggerSource,omitempty"`
+}
+
+// TriggerSource specifies the event that triggered a backup
+type TriggerSource struct {
+	// Name specifies the name of the trigger in the session
+	Name string `json:"name,omitempty"`
+
+	// Type specifies the type of the event
+	Type BackupTriggerType `json:"type,omitempty"`
+
+	// Object refers to the object the event happened on, i.e. the upgraded database,
+	// the resized PVC, the changed ConfigMap/Secret or the succeeded BackupSession.
+	// +optional
+	Object *kmapi.TypedObjectReference `json:"object,omitempty"`
+
+	// EventTime specifies when the event was observed
+	// +optional
+	EventTime *metav1.Time `json:"eventTime,omitempty"`
 }
 
 // BackupSessionStatus defines the observed state of BackupSession
@@ -80,6 +113,10 @@ type BackupSessionStatus struct {
 	// +optional
 	Duration string `json:"duration,omitempty"`
 
+	// DurationSeconds specifies the time required to complete the backup process in seconds
+	// +optional
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+
 	// BackupDeadline specifies the deadline of backup. Backup will be
 	// considered Failed if it does not complete within this deadline
 	// +optional
@@ -111,21 +148,42 @@ type BackupSessionStatus struct {
 	// +optional
 	NextRetry *metav1.Time `json:"nextRetry,omitempty"`
 
+	// Queue specifies the position of the BackupSession in the queue while it waits for a concurrency slot.
+	// This field will exist only while the BackupSession is in the "Queued" phase.
+	// +optional
+	Queue *QueueStatus `json:"queue,omitempty"`
+
 	// Conditions represents list of conditions regarding this BackupSession
 	// +optional
 	Conditions []kmapi.Condition `json:"conditions,omitempty"`
 }
 
+// QueueStatus represents the state of a BackupSession waiting for a concurrency slot
+type QueueStatus struct {
+	// Position specifies the position of the BackupSession in the queue, starting from 1.
+	Position int32 `json:"position,omitempty"`
+
+	// Reason specifies the concurrency limit the BackupSession is waiting for.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// EnqueueTime specifies when the BackupSession was put into the queue.
+	// +optional
+	EnqueueTime *metav1.Time `json:"enqueueTime,omitempty"`
+}
+
 // BackupSessionPhase specifies the current state of the backup process
-// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed;Skipped
+// +kubebuilder:validation:Enum=Pending;Queued;Running;Succeeded;PartiallySucceeded;Failed;Skipped
 type BackupSessionPhase string
 
 const (
-	BackupSessionPending   BackupSessionPhase = "Pending"
-	BackupSessionRunning   BackupSessionPhase = "Running"
-	BackupSessionSucceeded BackupSessionPhase = "Succeeded"
-	BackupSessionFailed    BackupSessionPhase = "Failed"
-	BackupSessionSkipped   BackupSessionPhase = "Skipped"
+	BackupSessionPending            BackupSessionPhase = "Pending"
+	BackupSessionQueued             BackupSessionPhase = "Queued"
+	BackupSessionRunning            BackupSessionPhase = "Running"
+	BackupSessionSucceeded          BackupSessionPhase = "Succeeded"
+	BackupSessionPartiallySucceeded BackupSessionPhase = "PartiallySucceeded"
+	BackupSessionFailed             BackupSessionPhase = "Failed"
+	BackupSessionSkipped            BackupSessionPhase = "Skipped"
 )
 
 // SnapshotStatus represents the current state of respective the Snapshot
@@ -177,6 +235,19 @@ const (
 	TypeBackupSkipped = "BackupSkipped"
 	// ReasonSkippedTakingNewBackup indicates that the backup was skipped because another backup was running or backup invoker is not ready state.
 	ReasonSkippedTakingNewBackup = "PreRequisitesNotSatisfied"
+	// ReasonSkippedInBlackoutPeriod indicates that the backup was skipped because it was scheduled inside a blackout period.
+	ReasonSkippedInBlackoutPeriod = "ScheduledInBlackoutPeriod"
+	// ReasonSkippedOutsideBackupWindow indicates that the backup was skipped because it was scheduled outside of the backup windows.
+	ReasonSkippedOutsideBackupWindow = "ScheduledOutsideBackupWindow"
+
+	// TypeBackupQueued indicates that the current session is waiting for a concurrency slot
+	TypeBackupQueued = "BackupQueued"
+	// ReasonBackupStorageConcurrencyLimitReached indicates that the maximum number of sessions are running against the BackupStorage
+	ReasonBackupStorageConcurrencyLimitReached = "BackupStorageConcurrencyLimitReached"
+	// ReasonNamespaceConcurrencyLimitReached indicates that the maximum number of sessions are running in the namespace
+	ReasonNamespaceConcurrencyLimitReached = "NamespaceConcurrencyLimitReached"
+	// ReasonNodeConcurrencyLimitReached indicates that the maximum number of sessions are running on the node
+	ReasonNodeConcurrencyLimitReached = "NodeConcurrencyLimitReached"
 
 	// TypeSessionHistoryCleaned indicates whether the backup history was cleaned or not according to backupHistoryLimit
 	TypeSessionHistoryCleaned               = "SessionHistoryCleaned"
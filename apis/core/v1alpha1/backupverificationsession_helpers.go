@@ -53,7 +53,8 @@ func (b *BackupVerificationSession) CalculatePhase() BackupVerificationSessionPh
 
 	if b.sessionHistoryCleanupSucceeded() &&
 		(b.failedToRestoreBackup() ||
-			b.failedToVerifyBackup()) {
+			b.failedToVerifyBackup() ||
+			b.HasFailedQuery()) {
 		return BackupVerificationSessionFailed
 	}
 
@@ -81,6 +82,42 @@ func (b *BackupVerificationSession) failedToVerifyBackup() bool {
 	return cutil.IsConditionFalse(b.Status.Conditions, TypeBackupVerified)
 }
 
+// HasFailedQuery returns whether any of the queries failed the check
+func (b *BackupVerificationSession) HasFailedQuery() bool {
+	for _, result := range b.Status.QueryResults {
+		if result.Phase == QueryFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// SetQueryResult records the result of a query. The result of a query with the same name is replaced.
+func (b *BackupVerificationSession) SetQueryResult(result QueryResult) {
+	for i := range b.Status.QueryResults {
+		if b.Status.QueryResults[i].Name == result.Name {
+			b.Status.QueryResults[i] = result
+			return
+		}
+	}
+	b.Status.QueryResults = append(b.Status.QueryResults, result)
+}
+
+// SetRestoreDuration records the restore times and the duration in the verification report
+func (r *VerificationReport) SetRestoreDuration(start, completion time.Time) {
+	r.RestoreStartTime = &metav1.Time{Time: start}
+	r.RestoreCompletionTime = &metav1.Time{Time: completion}
+	r.RestoreDuration = completion.Sub(start).Round(time.Second).String()
+}
+
+// SetScriptResult records the exit code and the tail of the output of the verification script
+func (r *VerificationReport) SetScriptResult(exitCode int32, output string) {
+	r.Script = &ScriptVerificationResult{
+		ExitCode: exitCode,
+		Output:   apis.TruncateTail(output, apis.MaxVerificationOutputSize),
+	}
+}
+
 func GenerateBackupVerificationSessionName(repoName, sessionName string) string {
 	return meta_util.ValidNameWithPrefixNSuffix(repoName, sessionName, fmt.Sprintf("%d", time.Now().Unix()))
 }
@@ -0,0 +1,37 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetScriptResult(t *testing.T) {
+	report := &VerificationReport{}
+	output := make([]byte, 5000)
+	for i := range output {
+		output[i] = 'a'
+	}
+	output[len(output)-1] = 'z'
+
+	report.SetScriptResult(1, string(output))
+	assert.Equal(t, int32(1), report.Script.ExitCode)
+	assert.Len(t, report.Script.Output, 4096)
+	assert.Equal(t, byte('z'), report.Script.Output[4095])
+}
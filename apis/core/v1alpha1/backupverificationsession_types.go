@@ -82,11 +82,150 @@ type BackupVerificationSessionStatus struct {
 	// +optional
 	Retried *bool `json:"retried,omitempty"`
 
+	// QueryResults specifies the result of each of the queries for query type verifier
+	// +optional
+	QueryResults []QueryResult `json:"queryResults,omitempty"`
+
+	// Report specifies the evidence collected during the backup verification
+	// +optional
+	Report *VerificationReport `json:"report,omitempty"`
+
+	// Sandbox specifies the state of the ephemeral namespace used for the backup verification
+	// +optional
+	Sandbox *SandboxStatus `json:"sandbox,omitempty"`
+
 	// Conditions represents list of conditions regarding this BackupSession
 	// +optional
 	Conditions []kmapi.Condition `json:"conditions,omitempty"`
 }
 
+// QueryResult specifies the result of a query run to verify the backup
+type QueryResult struct {
+	// Name specifies the name of the query
+	Name string `json:"name"`
+
+	// Phase specifies whether the query passed the check or not
+	// +optional
+	Phase QueryResultPhase `json:"phase,omitempty"`
+
+	// Expected specifies the expected result of the query
+	// +optional
+	Expected string `json:"expected,omitempty"`
+
+	// Actual specifies the actual result of the query
+	// +optional
+	Actual string `json:"actual,omitempty"`
+
+	// Error specifies the reason if the query failed
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// SandboxStatus specifies the state of the ephemeral namespace used for the backup verification
+type SandboxStatus struct {
+	// Namespace specifies the name of the sandbox namespace
+	Namespace string `json:"namespace,omitempty"`
+
+	// Phase represents the current state of the sandbox
+	// +optional
+	Phase SandboxPhase `json:"phase,omitempty"`
+
+	// CreationTime represents the timestamp when the sandbox was created
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// ExpiryTime represents the timestamp when the sandbox will be deleted regardless of the verification state
+	// +optional
+	ExpiryTime *metav1.Time `json:"expiryTime,omitempty"`
+}
+
+// SandboxPhase specifies the current state of the sandbox
+// +kubebuilder:validation:Enum=Provisioning;Ready;Deleting;Deleted
+type SandboxPhase string
+
+const (
+	SandboxProvisioning SandboxPhase = "Provisioning"
+	SandboxReady        SandboxPhase = "Ready"
+	SandboxDeleting     SandboxPhase = "Deleting"
+	SandboxDeleted      SandboxPhase = "Deleted"
+)
+
+// VerificationReport specifies the evidence collected during a backup verification
+type VerificationReport struct {
+	// RestoreSession specifies the name of the RestoreSession that restored the Snapshot
+	// +optional
+	RestoreSession string `json:"restoreSession,omitempty"`
+
+	// RestoreTarget specifies the target where the Snapshot has been restored
+	// +optional
+	RestoreTarget *kmapi.TypedObjectReference `json:"restoreTarget,omitempty"`
+
+	// RestoreStartTime represents the timestamp when the restore was started
+	// +optional
+	RestoreStartTime *metav1.Time `json:"restoreStartTime,omitempty"`
+
+	// RestoreCompletionTime represents the timestamp when the restore was completed
+	// +optional
+	RestoreCompletionTime *metav1.Time `json:"restoreCompletionTime,omitempty"`
+
+	// RestoreDuration specifies the time required to restore the Snapshot
+	// +optional
+	RestoreDuration string `json:"restoreDuration,omitempty"`
+
+	// RestoredSize specifies the size of the restored data
+	// +optional
+	RestoredSize string `json:"restoredSize,omitempty"`
+
+	// RestoredSizeBytes specifies the size of the restored data in bytes
+	// +optional
+	RestoredSizeBytes int64 `json:"restoredSizeBytes,omitempty"`
+
+	// Script specifies the result of the script for script type verifier
+	// +optional
+	Script *ScriptVerificationResult `json:"script,omitempty"`
+
+	// Artifact specifies where the exported report has been stored
+	// +optional
+	Artifact *VerificationReportArtifact `json:"artifact,omitempty"`
+}
+
+// ScriptVerificationResult specifies the result of the verification script
+type ScriptVerificationResult struct {
+	// ExitCode specifies the exit code of the script
+	ExitCode int32 `json:"exitCode"`
+
+	// Output specifies the tail of the output of the script, up to 4 KiB
+	// +optional
+	Output string `json:"output,omitempty"`
+}
+
+// VerificationReportArtifact specifies the exported verification report stored in the BackupStorage
+type VerificationReportArtifact struct {
+	// Path specifies the path of the report in the BackupStorage
+	Path string `json:"path,omitempty"`
+
+	// Digest specifies the SHA-256 digest of the report
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// Signature specifies the HMAC-SHA256 signature of the report. It is stored next to the report with ".sig" extension.
+	// +optional
+	Signature string `json:"signature,omitempty"`
+
+	// UploadTime represents the timestamp when the report was uploaded
+	// +optional
+	UploadTime *metav1.Time `json:"uploadTime,omitempty"`
+}
+
+// QueryResultPhase specifies whether a query passed the check or not
+// +kubebuilder:validation:Enum=Passed;Failed
+type QueryResultPhase string
+
+const (
+	QueryPassed QueryResultPhase = "Passed"
+	QueryFailed QueryResultPhase = "Failed"
+)
+
 // BackupVerificationSessionPhase specifies the current state of the backup verification process
 // +kubebuilder:validation:Enum=Running;Succeeded;Failed;Skipped
 type BackupVerificationSessionPhase string
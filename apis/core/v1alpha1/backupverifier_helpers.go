@@ -17,11 +17,134 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
 	"kubestash.dev/apimachinery/crds"
 
+	"k8s.io/utils/ptr"
 	"kmodules.xyz/client-go/apiextensions"
 )
 
 func (BackupVerifier) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
 	return crds.MustCustomResourceDefinition(GroupVersion.WithResource(ResourcePluralBackupVerifier))
 }
+
+// GetStrategyType returns the verification strategy. It is "Scheduled" when only the scheduler is
+// provided, and "LatestSnapshot" when neither the strategy nor the scheduler is provided.
+func (b *BackupVerifier) GetStrategyType() VerificationStrategyType {
+	if b.Spec.Strategy != nil && b.Spec.Strategy.Type != "" {
+		return b.Spec.Strategy.Type
+	}
+	if b.Spec.Scheduler != nil {
+		return VerifyScheduled
+	}
+	return VerifyLatestSnapshot
+}
+
+// IsVerificationDue returns whether a newly taken Snapshot should be verified according to the strategy.
+// The snapshots are the Snapshots of the Repository including the new one, and the sessions are the
+// BackupVerificationSessions of the Repository. It always returns false for "Scheduled" strategy,
+// as the verification is triggered by the scheduler instead.
+func (b *BackupVerifier) IsVerificationDue(snapshots []storageapi.Snapshot, sessions []BackupVerificationSession) bool {
+	switch b.GetStrategyType() {
+	case VerifyLatestSnapshot:
+		return true
+	case VerifyEveryNSnapshots:
+		return int32(len(getSnapshotsSinceLastVerification(snapshots, sessions))) >= ptr.Deref(b.Spec.Strategy.SnapshotInterval, 1)
+	default:
+		return false
+	}
+}
+
+func getSnapshotsSinceLastVerification(snapshots []storageapi.Snapshot, sessions []BackupVerificationSession) []storageapi.Snapshot {
+	// the Snapshots whose verification is still running are considered as attempted
+	verifying := make(map[string]bool)
+	for _, session := range sessions {
+		if !session.IsCompleted() {
+			verifying[session.Spec.Snapshot] = true
+		}
+	}
+
+	succeeded := getSucceededSnapshotsByTime(snapshots)
+	for i := len(succeeded) - 1; i >= 0; i-- {
+		if isVerificationAttempted(succeeded[i]) || verifying[succeeded[i].Name] {
+			return succeeded[i+1:]
+		}
+	}
+	return succeeded
+}
+
+// SelectSampleSnapshots randomly chooses the older Snapshots to verify along with the latest one
+// according to the sampling policy. The latest Snapshot is never chosen.
+func (b *BackupVerifier) SelectSampleSnapshots(snapshots []storageapi.Snapshot, now time.Time, r *rand.Rand) []string {
+	if b.Spec.Strategy == nil || b.Spec.Strategy.Sampling == nil {
+		return nil
+	}
+	sampling := b.Spec.Strategy.Sampling
+
+	succeeded := getSucceededSnapshotsByTime(snapshots)
+	if len(succeeded) < 2 {
+		return nil
+	}
+
+	var candidates []string
+	for _, snap := range succeeded[:len(succeeded)-1] {
+		if sampling.MaxAge != nil && now.Sub(snap.Status.SnapshotTime.Time) > sampling.MaxAge.Duration {
+			continue
+		}
+		candidates = append(candidates, snap.Name)
+	}
+
+	r.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	return candidates[:min(len(candidates), int(sampling.Count))]
+}
+
+// getSucceededSnapshotsByTime returns the succeeded Snapshots ordered from the oldest to the latest
+func getSucceededSnapshotsByTime(snapshots []storageapi.Snapshot) []storageapi.Snapshot {
+	var succeeded []storageapi.Snapshot
+	for _, snap := range snapshots {
+		if snap.Status.Phase == storageapi.SnapshotSucceeded && snap.Status.SnapshotTime != nil {
+			succeeded = append(succeeded, snap)
+		}
+	}
+	slices.SortStableFunc(succeeded, func(a, b storageapi.Snapshot) int {
+		return a.Status.SnapshotTime.Compare(b.Status.SnapshotTime.Time)
+	})
+	return succeeded
+}
+
+func isVerificationAttempted(snap storageapi.Snapshot) bool {
+	return snap.Status.VerificationStatus == storageapi.SnapshotVerified ||
+		snap.Status.VerificationStatus == storageapi.SnapshotVerificationFailed
+}
+
+func (s *SandboxSpec) GetNamespacePrefix() string {
+	if s.NamespacePrefix == "" {
+		return apis.DefaultSandboxPrefix
+	}
+	return s.NamespacePrefix
+}
+
+func (s *SandboxSpec) GetTTL() time.Duration {
+	if s.TTL == nil {
+		return apis.DefaultSandboxTTL
+	}
+	return s.TTL.Duration
+}
+
+func (s *SandboxSpec) GetOperatorNamespaces() []string {
+	if len(s.OperatorNamespaces) == 0 {
+		return []string{apis.DefaultSandboxOperatorNamespace}
+	}
+	return s.OperatorNamespaces
+}
+
+func (s *SandboxSpec) GetReplicas() int32 {
+	return ptr.Deref(s.Replicas, 1)
+}
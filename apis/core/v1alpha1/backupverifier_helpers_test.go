@@ -0,0 +1,139 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestBackupVerifierIsVerificationDue(t *testing.T) {
+	now := time.Now()
+	snapshots := []storageapi.Snapshot{
+		sampleVerifierSnapshot("snap-4", now, storageapi.SnapshotSucceeded, ""),
+		sampleVerifierSnapshot("snap-1", now.Add(-3*time.Hour), storageapi.SnapshotSucceeded, storageapi.SnapshotVerified),
+		sampleVerifierSnapshot("snap-2", now.Add(-2*time.Hour), storageapi.SnapshotSucceeded, ""),
+		sampleVerifierSnapshot("snap-3", now.Add(-time.Hour), storageapi.SnapshotFailed, ""),
+	}
+
+	tests := []struct {
+		name     string
+		spec     BackupVerifierSpec
+		sessions []BackupVerificationSession
+		expected bool
+	}{
+		{
+			name:     "Verification should be due for every Snapshot by default",
+			spec:     BackupVerifierSpec{},
+			expected: true,
+		},
+		{
+			name:     "Verification should not be due for Scheduled strategy",
+			spec:     BackupVerifierSpec{Scheduler: &SchedulerSpec{Schedule: "0 0 * * *"}},
+			expected: false,
+		},
+		{
+			name: "Verification should be due if the interval of succeeded Snapshots is reached",
+			spec: BackupVerifierSpec{Strategy: &VerificationStrategy{
+				Type:             VerifyEveryNSnapshots,
+				SnapshotInterval: ptr.To(int32(2)),
+			}},
+			expected: true,
+		},
+		{
+			name: "Verification should not be due if the interval of succeeded Snapshots is not reached",
+			spec: BackupVerifierSpec{Strategy: &VerificationStrategy{
+				Type:             VerifyEveryNSnapshots,
+				SnapshotInterval: ptr.To(int32(3)),
+			}},
+			expected: false,
+		},
+		{
+			name: "Verification should not be due if the verification of a Snapshot in the interval is running",
+			spec: BackupVerifierSpec{Strategy: &VerificationStrategy{
+				Type:             VerifyEveryNSnapshots,
+				SnapshotInterval: ptr.To(int32(2)),
+			}},
+			sessions: []BackupVerificationSession{
+				{
+					Spec:   BackupVerificationSessionSpec{Snapshot: "snap-2"},
+					Status: BackupVerificationSessionStatus{Phase: BackupVerificationSessionRunning},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "Verification should be due if the verification of a Snapshot in the interval is skipped",
+			spec: BackupVerifierSpec{Strategy: &VerificationStrategy{
+				Type:             VerifyEveryNSnapshots,
+				SnapshotInterval: ptr.To(int32(2)),
+			}},
+			sessions: []BackupVerificationSession{
+				{
+					Spec:   BackupVerificationSessionSpec{Snapshot: "snap-2"},
+					Status: BackupVerificationSessionStatus{Phase: BackupVerificationSessionSkipped},
+				},
+			},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			verifier := &BackupVerifier{Spec: test.spec}
+			assert.Equal(t, test.expected, verifier.IsVerificationDue(snapshots, test.sessions))
+		})
+	}
+}
+
+func TestBackupVerifierSelectSampleSnapshots(t *testing.T) {
+	now := time.Now()
+	var snapshots []storageapi.Snapshot
+	for i, name := range []string{"snap-0", "snap-1", "snap-2", "snap-3", "snap-4"} {
+		snapshots = append(snapshots, sampleVerifierSnapshot(name, now.Add(time.Duration(i-4)*24*time.Hour), storageapi.SnapshotSucceeded, ""))
+	}
+
+	verifier := &BackupVerifier{Spec: BackupVerifierSpec{Strategy: &VerificationStrategy{
+		Sampling: &VerificationSampling{Count: 2, MaxAge: &metav1.Duration{Duration: 60 * time.Hour}},
+	}}}
+
+	for seed := uint64(0); seed < 10; seed++ {
+		samples := verifier.SelectSampleSnapshots(snapshots, now, rand.New(rand.NewPCG(seed, seed)))
+		assert.Len(t, samples, 2)
+		assert.Subset(t, []string{"snap-2", "snap-3"}, samples, "only the older Snapshots within the max age should be sampled")
+	}
+
+	assert.Empty(t, (&BackupVerifier{}).SelectSampleSnapshots(snapshots, now, rand.New(rand.NewPCG(0, 0))))
+}
+
+func sampleVerifierSnapshot(name string, snapshotTime time.Time, phase storageapi.SnapshotPhase, status storageapi.VerificationStatus) storageapi.Snapshot {
+	return storageapi.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: storageapi.SnapshotStatus{
+			Phase:              phase,
+			SnapshotTime:       &metav1.Time{Time: snapshotTime},
+			VerificationStatus: status,
+		},
+	}
+}
@@ -49,9 +49,16 @@ type BackupVerifierSpec struct {
 	// +optional
 	RestoreOption *RestoreOption `json:"restoreOption,omitempty"`
 
-	// Scheduler specifies the configuration for verification triggering CronJob
+	// Scheduler specifies the configuration for verification triggering CronJob.
+	// It is required for "Scheduled" verification strategy.
+	// +optional
 	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
 
+	// Strategy specifies when the Snapshots will be verified.
+	// If it is not specified, the strategy is "Scheduled" when the `scheduler` is provided, otherwise "LatestSnapshot".
+	// +optional
+	Strategy *VerificationStrategy `json:"strategy,omitempty"`
+
 	// Function specifies the name of a Function CR that defines a container definition
 	// which will execute the verification logic for a particular application.
 	Function string `json:"function,omitempty"`
@@ -82,6 +89,11 @@ type BackupVerifierSpec struct {
 	// +optional
 	RetryConfig *RetryConfig `json:"retryConfig,omitempty"`
 
+	// ReportSigningKey refers to a key of a Secret that holds the key to sign the verification report.
+	// If it is provided, a signed verification report is uploaded to the BackupStorage after each verification.
+	// +optional
+	ReportSigningKey *core.SecretKeySelector `json:"reportSigningKey,omitempty"`
+
 	// SessionHistoryLimit specifies how many BackupVerificationSessions and associate resources KubeStash should keep for debugging purpose.
 	// The default value is 1.
 	// +kubebuilder:default=1
@@ -95,14 +107,97 @@ type BackupVerifierSpec struct {
 }
 
 type RestoreOption struct {
-	// Target indicates the target application where the data will be restored
+	// Target indicates the target application where the data will be restored.
+	// If the `sandbox` is provided, the target is cloned into the sandbox namespace and the data is restored into the clone.
 	// +optional
 	Target *kmapi.TypedObjectReference `json:"target,omitempty"`
 
+	// Sandbox specifies the configuration of an ephemeral namespace where the restore and the checks are run
+	// +optional
+	Sandbox *SandboxSpec `json:"sandbox,omitempty"`
+
 	// AddonInfo specifies addon configuration that will be used to restore this target.
 	AddonInfo *AddonInfo `json:"addonInfo,omitempty"`
 }
 
+// SandboxSpec specifies the configuration of an ephemeral namespace for the backup verification
+type SandboxSpec struct {
+	// NamespacePrefix specifies the prefix of the name of the sandbox namespace.
+	// The default value is "kubestash-sandbox".
+	// +optional
+	NamespacePrefix string `json:"namespacePrefix,omitempty"`
+
+	// TTL specifies how long the sandbox namespace is kept after it has been created.
+	// The sandbox is deleted once the verification is completed or the TTL is expired, whichever comes first.
+	// The default value is 2 hours.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// Replicas specifies the number of replicas of the cloned target. The default value is 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// StorageClassName overrides the storage class of the cloned target
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// DisableNetworkIsolation specifies whether to allow the traffic between the sandbox namespace and the other namespaces.
+	// By default, NetworkPolicies are created that only allow the traffic within the sandbox namespace, the traffic from
+	// the operator namespaces, the DNS lookups and the traffic to the Kubernetes API server.
+	// Only the restore job is allowed to reach the backend storage.
+	// +optional
+	DisableNetworkIsolation bool `json:"disableNetworkIsolation,omitempty"`
+
+	// OperatorNamespaces specifies the namespaces of the operators that manage the cloned target, i.e. KubeDB.
+	// The operators are allowed to reach the isolated sandbox namespace. The default value is ["kubedb"].
+	// +optional
+	OperatorNamespaces []string `json:"operatorNamespaces,omitempty"`
+
+	// ResourceQuota specifies the hard limits of the compute and storage resources that can be used in the sandbox namespace
+	// +optional
+	ResourceQuota core.ResourceList `json:"resourceQuota,omitempty"`
+}
+
+// VerificationStrategy specifies when the Snapshots will be verified
+type VerificationStrategy struct {
+	// Type specifies the cadence of the verification.
+	// Valid values are:
+	// - "LatestSnapshot": Verify every new Snapshot once it has been taken.
+	// - "EveryNSnapshots": Verify a new Snapshot once `snapshotInterval` number of Snapshots have been taken since the last verified one.
+	// - "Scheduled": Verify the latest Snapshot according to the schedule of the `scheduler`.
+	// +optional
+	Type VerificationStrategyType `json:"type,omitempty"`
+
+	// SnapshotInterval specifies the number of Snapshots between two verifications for "EveryNSnapshots" strategy
+	// +optional
+	SnapshotInterval *int32 `json:"snapshotInterval,omitempty"`
+
+	// Sampling specifies how to verify some randomly chosen older Snapshots along with the latest one
+	// +optional
+	Sampling *VerificationSampling `json:"sampling,omitempty"`
+}
+
+// VerificationStrategyType specifies the cadence of the verification
+// +kubebuilder:validation:Enum=LatestSnapshot;EveryNSnapshots;Scheduled
+type VerificationStrategyType string
+
+const (
+	VerifyLatestSnapshot  VerificationStrategyType = "LatestSnapshot"
+	VerifyEveryNSnapshots VerificationStrategyType = "EveryNSnapshots"
+	VerifyScheduled       VerificationStrategyType = "Scheduled"
+)
+
+// VerificationSampling specifies how to choose the older Snapshots to verify
+type VerificationSampling struct {
+	// Count specifies the number of older Snapshots that will be randomly chosen in each verification
+	Count int32 `json:"count"`
+
+	// MaxAge specifies the maximum age of the Snapshots that can be chosen.
+	// If it is not specified, any Snapshot in the Repository can be chosen.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
 // VerificationType specifies the type of verifier that will verify the backup
 // +kubebuilder:validation:Enum=RestoreOnly;Query;Script
 type VerificationType string
@@ -17,10 +17,17 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/crds"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
 	"kmodules.xyz/client-go/apiextensions"
 	meta_util "kmodules.xyz/client-go/meta"
 )
@@ -50,3 +57,123 @@ func (h *HookTemplate) OffshootLabels() map[string]string {
 
 	return apis.UpsertLabels(h.Labels, newLabels)
 }
+
+// ParseOutputs extracts the outputs emitted by the hook from its standard output.
+// The lines that emit an output not defined in the HookTemplate are ignored.
+// If an output is emitted multiple times, the last value is used.
+func (h *HookTemplate) ParseOutputs(stdout string) []HookOutput {
+	defined := make(map[string]bool)
+	for _, def := range h.Spec.Outputs {
+		defined[def.Name] = true
+	}
+
+	values := make(map[string]string)
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, apis.HookOutputPrefix) {
+			continue
+		}
+		name, value, found := strings.Cut(strings.TrimSpace(strings.TrimPrefix(line, apis.HookOutputPrefix)), "=")
+		if found && defined[name] {
+			values[name] = value
+		}
+	}
+
+	var outputs []HookOutput
+	for _, def := range h.Spec.Outputs {
+		if value, ok := values[def.Name]; ok {
+			outputs = append(outputs, HookOutput{Name: def.Name, Value: value})
+		}
+	}
+	return outputs
+}
+
+// GetMethod returns the HTTP method of the request. POST is used by default.
+func (h *HTTPHookExecutorSpec) GetMethod() HTTPMethod {
+	if h.Method == "" {
+		return HTTPMethodPost
+	}
+	return h.Method
+}
+
+// IsExpectedStatusCode returns whether the given response status code indicates a successful execution of the hook
+func (h *HTTPHookExecutorSpec) IsExpectedStatusCode(code int) bool {
+	if len(h.ExpectedStatusCodes) == 0 {
+		return code >= 200 && code < 300
+	}
+	return slices.Contains(h.ExpectedStatusCodes, int32(code))
+}
+
+// GetSelector returns the label selector to select the hook executor pods. It combines the
+// requirements of the `selector` and the `labelSelector` fields.
+func (p *PodHookExecutorSpec) GetSelector() (labels.Selector, error) {
+	if p.Selector == "" && p.LabelSelector == nil {
+		return nil, fmt.Errorf("either selector or labelSelector must be specified for pod type executor")
+	}
+
+	selector, err := labels.Parse(p.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %q. Reason: %w", p.Selector, err)
+	}
+	if p.LabelSelector != nil {
+		labelSelector, err := metav1.LabelSelectorAsSelector(p.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labelSelector. Reason: %w", err)
+		}
+		requirements, _ := labelSelector.Requirements()
+		selector = selector.Add(requirements...)
+	}
+	if selector.Empty() {
+		return nil, fmt.Errorf("selector and labelSelector can not be both empty for pod type executor, as it would select all the pods")
+	}
+	return selector, nil
+}
+
+// SelectPods returns the pods where the hook should be executed according to the strategy.
+// Only the running pods are considered and they are ordered by their names.
+func (p *PodHookExecutorSpec) SelectPods(pods []corev1.Pod) []corev1.Pod {
+	var running []corev1.Pod
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			running = append(running, pod)
+		}
+	}
+	slices.SortFunc(running, func(a, b corev1.Pod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	switch p.Strategy {
+	case ExecuteOnAll:
+		return running
+	case ExecuteOnPrimary, ExecuteOnLeader:
+		key, value := p.getRole()
+		return slices.DeleteFunc(running, func(pod corev1.Pod) bool {
+			return pod.Labels[key] != value
+		})
+	case ExecuteOnN:
+		return running[:min(len(running), int(ptr.Deref(p.Count, 1)))]
+	default:
+		return running[:min(len(running), 1)]
+	}
+}
+
+func (p *PodHookExecutorSpec) getRole() (string, string) {
+	key, value := apis.KubeDBRoleLabelKey, apis.RolePrimary
+	if p.Strategy == ExecuteOnLeader {
+		value = apis.RoleLeader
+	}
+	if p.Role != nil {
+		if p.Role.LabelKey != "" {
+			key = p.Role.LabelKey
+		}
+		if p.Role.Value != "" {
+			value = p.Role.Value
+		}
+	}
+	return key, value
+}
+
+// GetParallelism returns the maximum number of pods where the hook will be executed at the same time
+func (p *PodHookExecutorSpec) GetParallelism() int {
+	return max(int(ptr.Deref(p.Parallelism, 1)), 1)
+}
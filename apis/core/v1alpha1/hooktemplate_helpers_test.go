@@ -0,0 +1,230 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"kubestash.dev/apimachinery/apis"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+)
+
+func TestHookTemplateParseOutputs(t *testing.T) {
+	ht := &HookTemplate{
+		Spec: HookTemplateSpec{
+			Outputs: []HookOutputDefinition{{Name: "binlog-position"}, {Name: "tables"}},
+		},
+	}
+
+	stdout := `flushing binary logs...
+kubestash-output: binlog-position=mysql-bin.000042:154
+kubestash-output: undeclared=ignored
+  kubestash-output: tables=users,orders
+kubestash-output: binlog-position=mysql-bin.000042:1024
+done`
+
+	assert.Equal(t, []HookOutput{
+		{Name: "binlog-position", Value: "mysql-bin.000042:1024"},
+		{Name: "tables", Value: "users,orders"},
+	}, ht.ParseOutputs(stdout))
+}
+
+func TestHookStatusOutputVariables(t *testing.T) {
+	status := HookStatus{}
+	status.SetPreHookStatus(HookExecutionStatus{Name: "flush-logs", Phase: HookExecutionPending})
+	status.SetPreHookStatus(HookExecutionStatus{
+		Name:    "flush-logs",
+		Phase:   HookExecutionSucceeded,
+		Outputs: []HookOutput{{Name: "binlog-position", Value: "mysql-bin.000042:154"}},
+	})
+	status.SetPostHookStatus(HookExecutionStatus{
+		Name:    "unfreeze",
+		Phase:   HookExecutionFailed,
+		Outputs: []HookOutput{{Name: "token", Value: "abc"}},
+	})
+	status.SetPostHookStatus(HookExecutionStatus{
+		Name:    "flush-logs",
+		Phase:   HookExecutionSucceeded,
+		Outputs: []HookOutput{{Name: "binlog-position", Value: "mysql-bin.000043:4"}},
+	})
+
+	assert.Len(t, status.PreHooks, 1)
+	assert.Equal(t, map[string]string{
+		"HOOK_PRE_FLUSH_LOGS__BINLOG_POSITION":  "mysql-bin.000042:154",
+		"HOOK_POST_FLUSH_LOGS__BINLOG_POSITION": "mysql-bin.000043:4",
+	}, status.GetOutputVariables())
+}
+
+func TestHookOutputVariableName(t *testing.T) {
+	assert.Equal(t, "HOOK_PRE_FLUSH_LOGS__BINLOG_POSITION", HookOutputVariableName(apis.HookStagePre, "flush-logs", "binlog-position"))
+	assert.Equal(t, "HOOK_POST_FLUSH_LOGS__BINLOG_POSITION", HookOutputVariableName(apis.HookStagePost, "-flush--logs", "binlog_position_"))
+	assert.NotEqual(t, HookOutputVariableName(apis.HookStagePre, "a-b", "c"), HookOutputVariableName(apis.HookStagePre, "a", "b-c"))
+}
+
+func TestSetStdoutKeepsRunesIntact(t *testing.T) {
+	status := HookExecutionStatus{}
+	status.SetStdout(strings.Repeat("é", apis.MaxHookStdoutSize/2) + "x")
+
+	assert.True(t, utf8.ValidString(status.Stdout))
+	assert.Equal(t, strings.Repeat("é", apis.MaxHookStdoutSize/2-1)+"x", status.Stdout)
+}
+
+func TestValidateHookNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		preHooks  []HookInfo
+		postHooks []HookInfo
+		wantErr   bool
+	}{
+		{
+			name:      "Hook names should be valid if their output variable names are different",
+			preHooks:  []HookInfo{{Name: "flush-logs"}},
+			postHooks: []HookInfo{{Name: "unfreeze"}},
+			wantErr:   false,
+		},
+		{
+			name:     "Hook names should be invalid if they only differ in the characters not allowed in a variable name",
+			preHooks: []HookInfo{{Name: "flush-logs"}, {Name: "flush_logs"}},
+			wantErr:  true,
+		},
+		{
+			name:      "Hook names should be valid if a pre hook and a post hook have the same name",
+			preHooks:  []HookInfo{{Name: "flush-logs"}},
+			postHooks: []HookInfo{{Name: "flush-logs"}},
+			wantErr:   false,
+		},
+		{
+			name:      "Hook names should be invalid if two post hooks have the same output variable name",
+			postHooks: []HookInfo{{Name: "flush-logs"}, {Name: "flush__logs"}},
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateHookNames(test.preHooks, test.postHooks)
+			assert.Equal(t, test.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestPodHookExecutorGetSelector(t *testing.T) {
+	pod := &PodHookExecutorSpec{
+		Selector: "app=mysql",
+		LabelSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod", "staging"}},
+			},
+		},
+	}
+
+	selector, err := pod.GetSelector()
+	assert.Nil(t, err)
+	assert.True(t, selector.Matches(labels.Set{"app": "mysql", "env": "prod"}))
+	assert.False(t, selector.Matches(labels.Set{"app": "mysql", "env": "dev"}))
+	assert.False(t, selector.Matches(labels.Set{"app": "postgres", "env": "prod"}))
+
+	_, err = (&PodHookExecutorSpec{}).GetSelector()
+	assert.NotNil(t, err, "empty selector should not select all the pods")
+
+	_, err = (&PodHookExecutorSpec{LabelSelector: &metav1.LabelSelector{}}).GetSelector()
+	assert.NotNil(t, err, "empty labelSelector should not select all the pods")
+
+	_, err = (&PodHookExecutorSpec{Selector: "app in (mysql"}).GetSelector()
+	assert.NotNil(t, err)
+}
+
+func TestPodHookExecutorSelectPods(t *testing.T) {
+	newPod := func(name, role string, phase corev1.PodPhase) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubedb.com/role": role, "raft/role": role}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	pods := []corev1.Pod{
+		newPod("mysql-2", "standby", corev1.PodRunning),
+		newPod("mysql-1", "primary", corev1.PodRunning),
+		newPod("mysql-0", "standby", corev1.PodRunning),
+		newPod("mysql-3", "leader", corev1.PodPending),
+		newPod("mysql-4", "leader", corev1.PodRunning),
+	}
+
+	tests := []struct {
+		name     string
+		spec     PodHookExecutorSpec
+		expected []string
+	}{
+		{
+			name:     "ExecuteOnOne should select the first running pod",
+			spec:     PodHookExecutorSpec{Strategy: ExecuteOnOne},
+			expected: []string{"mysql-0"},
+		},
+		{
+			name:     "ExecuteOnAll should select all the running pods",
+			spec:     PodHookExecutorSpec{Strategy: ExecuteOnAll},
+			expected: []string{"mysql-0", "mysql-1", "mysql-2", "mysql-4"},
+		},
+		{
+			name:     "ExecuteOnPrimary should select the pod with the primary role",
+			spec:     PodHookExecutorSpec{Strategy: ExecuteOnPrimary},
+			expected: []string{"mysql-1"},
+		},
+		{
+			name:     "ExecuteOnLeader should select the running pod with the leader role of the custom label",
+			spec:     PodHookExecutorSpec{Strategy: ExecuteOnLeader, Role: &PodRoleSpec{LabelKey: "raft/role"}},
+			expected: []string{"mysql-4"},
+		},
+		{
+			name:     "ExecuteOnN should select the first N running pods",
+			spec:     PodHookExecutorSpec{Strategy: ExecuteOnN, Count: ptr.To(int32(2))},
+			expected: []string{"mysql-0", "mysql-1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var names []string
+			for _, pod := range test.spec.SelectPods(pods) {
+				names = append(names, pod.Name)
+			}
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
+
+func TestHookExecutionStatusGetPodsPhase(t *testing.T) {
+	status := HookExecutionStatus{Name: "flush-tables"}
+	assert.Equal(t, HookExecutionPending, status.GetPodsPhase())
+
+	status.SetPodStatus(PodHookExecutionStatus{Name: "mysql-0", Phase: HookExecutionSucceeded})
+	status.SetPodStatus(PodHookExecutionStatus{Name: "mysql-1", Phase: HookExecutionPending})
+	assert.Equal(t, HookExecutionPending, status.GetPodsPhase())
+
+	status.SetPodStatus(PodHookExecutionStatus{Name: "mysql-1", Phase: HookExecutionFailed, Error: "exit code 1"})
+	assert.Len(t, status.Pods, 2)
+	assert.Equal(t, HookExecutionFailed, status.GetPodsPhase())
+
+	status.SetPodStatus(PodHookExecutionStatus{Name: "mysql-1", Phase: HookExecutionSucceeded})
+	assert.Equal(t, HookExecutionSucceeded, status.GetPodsPhase())
+}
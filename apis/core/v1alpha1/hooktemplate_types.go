@@ -60,6 +60,7 @@ type HookTemplateSpec struct {
 	UsagePolicy *apis.UsagePolicy `json:"usagePolicy,omitempty"`
 
 	// Params defines a list of parameters that is used by the HookTemplate to execute its logic.
+	// If any of the parameters specifies a type, the hooks can not pass the parameters that are not defined here.
 	// +optional
 	Params []apis.ParameterDefinition `json:"params,omitempty"`
 
@@ -73,6 +74,22 @@ type HookTemplateSpec struct {
 
 	// Executor specifies the entity where the hook will be executed.
 	Executor *HookExecutor `json:"executor,omitempty"`
+
+	// Outputs defines the named values emitted by the hook. The hook emits an output by printing
+	// a line in "kubestash-output: <name>=<value>" format in its standard output.
+	// The outputs are recorded in the hook status and can be used by the following hooks and tasks.
+	// +optional
+	Outputs []HookOutputDefinition `json:"outputs,omitempty"`
+}
+
+// HookOutputDefinition defines a named value emitted by a hook
+type HookOutputDefinition struct {
+	// Name specifies the name of the output
+	Name string `json:"name"`
+
+	// Usage specifies the usage of this output
+	// +optional
+	Usage string `json:"usage,omitempty"`
 }
 
 // HookExecutor specifies the entity specification which is responsible for executing the hook
@@ -82,6 +99,7 @@ type HookExecutor struct {
 	// - "Function": KubeStash will create a job with the provided information in `function` section. The job will execute the hook.
 	// - "Pod": KubeStash will select the pod that matches the selector provided in `pod` section. This pod(s) will execute the hook.
 	// - "Operator": KubeStash operator itself will execute the hook.
+	// - "HTTP": KubeStash operator will send the HTTP request provided in `http` section.
 	Type HookExecutorType `json:"type,omitempty"`
 
 	// Function specifies the function information which will be used to create the hook executor job.
@@ -91,16 +109,21 @@ type HookExecutor struct {
 	// Pod specifies the criteria to use to select the hook executor pods
 	// +optional
 	Pod *PodHookExecutorSpec `json:"pod,omitempty"`
+
+	// HTTP specifies the HTTP request that will be sent to execute the hook
+	// +optional
+	HTTP *HTTPHookExecutorSpec `json:"http,omitempty"`
 }
 
 // HookExecutorType specifies the type of entity that will execute the hook
-// +kubebuilder:validation:Enum=Function;Pod;Operator
+// +kubebuilder:validation:Enum=Function;Pod;Operator;HTTP
 type HookExecutorType string
 
 const (
 	HookExecutorFunction HookExecutorType = "Function"
 	HookExecutorPod      HookExecutorType = "Pod"
 	HookExecutorOperator HookExecutorType = "Operator"
+	HookExecutorHTTP     HookExecutorType = "HTTP"
 )
 
 // FunctionHookExecutorSpec defines function and its parameters that will be used to create hook executor job
@@ -124,9 +147,23 @@ type FunctionHookExecutorSpec struct {
 // PodHookExecutorSpec specifies the criteria that will be used to select the pod which will execute the hook
 type PodHookExecutorSpec struct {
 	// Selector specifies list of key value pair that will be used as label selector to select the desired pods.
-	// You can use comma to separate multiple labels (i.e. "app=my-app,env=prod")
+	// You can use comma to separate multiple labels (i.e. "app=my-app,env=prod").
+	// It is combined with the `labelSelector` if both are specified.
+	//
+	// Deprecated: Use `labelSelector` instead.
+	// +optional
 	Selector string `json:"selector,omitempty"`
 
+	// LabelSelector specifies the label selector to select the desired pods.
+	// It supports both equality-based and set-based requirements.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// Container specifies the name of the container where the hook will be executed.
+	// If it is not specified, the hook will be executed in the first container of the pod.
+	// +optional
+	Container string `json:"container,omitempty"`
+
 	// Owner specifies a template for owner reference that will be used to filter the selected pods.
 	// +optional
 	Owner *metav1.OwnerReference `json:"owner,omitempty"`
@@ -135,19 +172,118 @@ type PodHookExecutorSpec struct {
 	// Valid values are:
 	// - "ExecuteOnOne": Execute hook on only one of the selected pods. This is default behavior
 	// - "ExecuteOnAll": Execute hook on all the selected pods.
+	// - "ExecuteOnPrimary": Execute hook on the selected pod that has the primary role.
+	// - "ExecuteOnLeader": Execute hook on the selected pod that has the leader role.
+	// - "ExecuteOnN": Execute hook on `count` number of the selected pods.
 	// +kubebuilder:default=ExecuteOnOne
 	Strategy PodHookExecutionStrategy `json:"strategy,omitempty"`
+
+	// Role specifies how to identify the role of the selected pods for "ExecuteOnPrimary" and "ExecuteOnLeader" strategies.
+	// By default, the role is identified by the "kubedb.com/role" label.
+	// +optional
+	Role *PodRoleSpec `json:"role,omitempty"`
+
+	// Count specifies the number of pods where the hook will be executed for "ExecuteOnN" strategy
+	// +optional
+	Count *int32 `json:"count,omitempty"`
+
+	// Parallelism specifies the maximum number of pods where the hook will be executed at the same time.
+	// The hook is executed in the pods one by one by default.
+	// +optional
+	Parallelism *int32 `json:"parallelism,omitempty"`
+}
+
+// PodRoleSpec specifies how to identify the role of a pod
+type PodRoleSpec struct {
+	// LabelKey specifies the key of the label that holds the role of the pod.
+	// The default value is "kubedb.com/role".
+	// +optional
+	LabelKey string `json:"labelKey,omitempty"`
+
+	// Value specifies the value of the label for the desired role.
+	// The default value is "primary" for "ExecuteOnPrimary" strategy and "leader" for "ExecuteOnLeader" strategy.
+	// +optional
+	Value string `json:"value,omitempty"`
 }
 
 // PodHookExecutionStrategy specifies the strategy to follow when multiple pods are selected for hook execution
-// +kubebuilder:validation:Enum=ExecuteOnOne;ExecuteOnAll
+// +kubebuilder:validation:Enum=ExecuteOnOne;ExecuteOnAll;ExecuteOnPrimary;ExecuteOnLeader;ExecuteOnN
 type PodHookExecutionStrategy string
 
 const (
-	ExecuteOnOne PodHookExecutionStrategy = "ExecuteOnOne"
-	ExecuteOnAll PodHookExecutionStrategy = "ExecuteOnAll"
+	ExecuteOnOne     PodHookExecutionStrategy = "ExecuteOnOne"
+	ExecuteOnAll     PodHookExecutionStrategy = "ExecuteOnAll"
+	ExecuteOnPrimary PodHookExecutionStrategy = "ExecuteOnPrimary"
+	ExecuteOnLeader  PodHookExecutionStrategy = "ExecuteOnLeader"
+	ExecuteOnN       PodHookExecutionStrategy = "ExecuteOnN"
 )
 
+// HTTPHookExecutorSpec specifies the HTTP request that will be sent to execute the hook.
+// The URL, the header values and the body can refer to the hook parameters and the variables
+// using "${VARIABLE}" syntax.
+type HTTPHookExecutorSpec struct {
+	// Method specifies the HTTP method of the request
+	// +kubebuilder:default=POST
+	// +optional
+	Method HTTPMethod `json:"method,omitempty"`
+
+	// URL specifies the URL where the request will be sent
+	URL string `json:"url"`
+
+	// Headers specifies the headers of the request
+	// +optional
+	Headers []HTTPHeader `json:"headers,omitempty"`
+
+	// Body specifies the body of the request. The variables in the body are substituted with JSON escaped
+	// values, so that they can be safely used inside the JSON strings.
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// ExpectedStatusCodes specifies the response status codes that indicate a successful execution of the hook.
+	// If it is not specified, any 2xx status code is considered successful.
+	// +optional
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty"`
+
+	// MaxRetry specifies how many times the request should be retried on a connection
+	// failure, a timeout or a 5xx or 429 response status code.
+	// +optional
+	MaxRetry int32 `json:"maxRetry,omitempty"`
+
+	// RetryInterval specifies the initial interval between the retries. The interval grows exponentially.
+	// +optional
+	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
+
+	// Timeout specifies how long to wait for the response of a single request. The default is 30 seconds.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// HTTPMethod specifies the HTTP method of a request
+// +kubebuilder:validation:Enum=GET;POST;PUT;PATCH;DELETE
+type HTTPMethod string
+
+const (
+	HTTPMethodGet    HTTPMethod = "GET"
+	HTTPMethodPost   HTTPMethod = "POST"
+	HTTPMethodPut    HTTPMethod = "PUT"
+	HTTPMethodPatch  HTTPMethod = "PATCH"
+	HTTPMethodDelete HTTPMethod = "DELETE"
+)
+
+// HTTPHeader specifies a header of an HTTP request
+type HTTPHeader struct {
+	// Name specifies the name of the header
+	Name string `json:"name"`
+
+	// Value specifies the value of the header
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// SecretKeyRef refers to a key of a Secret in the namespace of the hook invoker that holds the value of the header
+	// +optional
+	SecretKeyRef *core.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 
 // HookTemplateList contains a list of HookTemplate
@@ -0,0 +1,256 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	dbapi "kubedb.dev/apimachinery/apis/kubedb/v1"
+	olddbapi "kubedb.dev/apimachinery/apis/kubedb/v1alpha2"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DefaultPostgresSchema is the schema of the table checked by a PostgresQuery when no schema is specified
+const DefaultPostgresSchema = "public"
+
+// VerificationQuery represents a check that is run against the restored database to verify the backup
+// +k8s:deepcopy-gen=false
+type VerificationQuery interface {
+	GetName() string
+	Validate() error
+}
+
+var queryDecoders = map[string]func([]byte) ([]VerificationQuery, error){
+	dbapi.ResourceKindMySQL:          decodeQueries[MySQLQuery],
+	dbapi.ResourceKindMariaDB:        decodeQueries[MariaDBQuery],
+	dbapi.ResourceKindPostgres:       decodeQueries[PostgresQuery],
+	dbapi.ResourceKindMongoDB:        decodeQueries[MongoDBQuery],
+	dbapi.ResourceKindElasticsearch:  decodeQueries[ElasticsearchQuery],
+	dbapi.ResourceKindRedis:          decodeQueries[RedisQuery],
+	olddbapi.ResourceKindSinglestore: decodeQueries[SinglestoreQuery],
+	olddbapi.ResourceKindMSSQLServer: decodeQueries[MSSQLServerQuery],
+}
+
+// DecodeQueries decodes the queries of a query type BackupVerifier into the query type of the given
+// restore target kind. The queries can be provided either as a single object or as a list of objects.
+func DecodeQueries(kind string, raw *runtime.RawExtension) ([]VerificationQuery, error) {
+	decode, ok := queryDecoders[kind]
+	if !ok {
+		return nil, fmt.Errorf("query based verification is not supported for kind %q", kind)
+	}
+	if raw == nil || len(raw.Raw) == 0 {
+		return nil, fmt.Errorf("query can not be empty")
+	}
+	return decode(raw.Raw)
+}
+
+func decodeQueries[T VerificationQuery](data []byte) ([]VerificationQuery, error) {
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, []byte("[")) {
+		data = append(append([]byte("["), data...), ']')
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var typed []T
+	if err := decoder.Decode(&typed); err != nil {
+		return nil, fmt.Errorf("failed to decode query. Reason: %w", err)
+	}
+
+	queries := make([]VerificationQuery, 0, len(typed))
+	for _, q := range typed {
+		queries = append(queries, q)
+	}
+	return queries, nil
+}
+
+// GetQueryName returns the name of the query at the given index of the queries
+func GetQueryName(index int, query VerificationQuery) string {
+	if query.GetName() != "" {
+		return query.GetName()
+	}
+	return fmt.Sprintf("query-%d", index)
+}
+
+// ValidateQueries validates the queries and ensures that their names are unique
+func ValidateQueries(queries []VerificationQuery) error {
+	if len(queries) == 0 {
+		return fmt.Errorf("query can not be empty")
+	}
+	names := make(map[string]bool)
+	for i, q := range queries {
+		name := GetQueryName(i, q)
+		if names[name] {
+			return fmt.Errorf("duplicate query name found: %q", name)
+		}
+		names[name] = true
+		if err := q.Validate(); err != nil {
+			return fmt.Errorf("invalid query %q. Reason: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (q MySQLQuery) GetName() string { return q.Name }
+
+func (q MySQLQuery) Validate() error {
+	return validateSQLQuery(q.Database, q.Table, q.RowCount, q.Checksum, q.SQL)
+}
+
+func (q MariaDBQuery) GetName() string { return q.Name }
+
+func (q MariaDBQuery) Validate() error {
+	return validateSQLQuery(q.Database, q.Table, q.RowCount, q.Checksum, q.SQL)
+}
+
+func (q PostgresQuery) GetName() string { return q.Name }
+
+// GetSchema returns the schema of the table to check. It defaults to DefaultPostgresSchema.
+func (q PostgresQuery) GetSchema() string {
+	if q.Schema == "" {
+		return DefaultPostgresSchema
+	}
+	return q.Schema
+}
+
+func (q PostgresQuery) Validate() error {
+	return validateSQLQuery(q.Database, q.Table, q.RowCount, q.Checksum, q.SQL)
+}
+
+func (q SinglestoreQuery) GetName() string { return q.Name }
+
+func (q SinglestoreQuery) Validate() error {
+	return validateSQLQuery(q.Database, q.Table, q.RowCount, q.Checksum, q.SQL)
+}
+
+func (q MSSQLServerQuery) GetName() string { return q.Name }
+
+func (q MSSQLServerQuery) Validate() error {
+	return validateSQLQuery(q.Database, q.Table, q.RowCount, q.Checksum, q.SQL)
+}
+
+func (q MongoDBQuery) GetName() string { return q.Name }
+
+func (q MongoDBQuery) Validate() error {
+	if q.Database == "" {
+		return fmt.Errorf("database can not be empty")
+	}
+	if q.DocumentCount != nil {
+		if q.Collection == "" {
+			return fmt.Errorf("collection is required to check the document count")
+		}
+		return q.DocumentCount.Validate()
+	}
+	return nil
+}
+
+func (q ElasticsearchQuery) GetName() string { return q.Name }
+
+func (q ElasticsearchQuery) Validate() error {
+	if q.Index == "" {
+		return fmt.Errorf("index can not be empty")
+	}
+	return nil
+}
+
+func (q RedisQuery) GetName() string { return q.Name }
+
+func (q RedisQuery) Validate() error {
+	if q.Index < 0 {
+		return fmt.Errorf("index can not be negative")
+	}
+	if q.DbSize != nil {
+		return q.DbSize.Validate()
+	}
+	return nil
+}
+
+func validateSQLQuery(database, table string, rowCount *MatchExpression, checksum string, sql *ScalarQuery) error {
+	if database == "" {
+		return fmt.Errorf("database can not be empty")
+	}
+	if rowCount != nil {
+		if table == "" {
+			return fmt.Errorf("table is required to check the row count")
+		}
+		if err := rowCount.Validate(); err != nil {
+			return err
+		}
+	}
+	if checksum != "" && table == "" {
+		return fmt.Errorf("table is required to check the checksum")
+	}
+	if sql != nil {
+		if sql.Statement == "" {
+			return fmt.Errorf("statement of sql query can not be empty")
+		}
+		if sql.Result == nil {
+			return fmt.Errorf("result of sql query can not be empty")
+		}
+		if err := sql.Result.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var operators = []Operator{
+	EqualOperator,
+	NotEqualOperator,
+	LessThanOperator,
+	LessThanOrEqualOperator,
+	GreaterThanOperator,
+	GreaterThanOrEqualOperator,
+}
+
+// Validate checks whether the match expression has a valid operator and a value
+func (m *MatchExpression) Validate() error {
+	if !slices.Contains(operators, m.Operator) {
+		return fmt.Errorf("invalid operator %q", m.Operator)
+	}
+	if m.Value == nil {
+		return fmt.Errorf("value can not be empty for operator %q", m.Operator)
+	}
+	return nil
+}
+
+// Matches returns whether the given value satisfies the match expression
+func (m *MatchExpression) Matches(value int64) bool {
+	if m.Value == nil {
+		return false
+	}
+	expected := *m.Value
+	switch m.Operator {
+	case EqualOperator:
+		return value == expected
+	case NotEqualOperator:
+		return value != expected
+	case LessThanOperator:
+		return value < expected
+	case LessThanOrEqualOperator:
+		return value <= expected
+	case GreaterThanOperator:
+		return value > expected
+	case GreaterThanOrEqualOperator:
+		return value >= expected
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestDecodeQueries(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		query   string
+		count   int
+		wantErr bool
+	}{
+		{
+			name:  "Queries should be valid if a single query is provided",
+			kind:  "MongoDB",
+			query: `{"database": "shop", "collection": "orders", "documentCount": {"operator": "GreaterThan", "value": 0}}`,
+			count: 1,
+		},
+		{
+			name: "Queries should be valid if multiple checks are provided",
+			kind: "MySQL",
+			query: `[
+				{"name": "users", "database": "shop", "table": "users", "rowCount": {"operator": "Equal", "value": 10}},
+				{"name": "orders", "database": "shop", "table": "orders", "checksum": "3421587462"},
+				{"name": "revenue", "database": "shop", "sql": {"statement": "SELECT SUM(total) FROM orders", "result": {"operator": "GreaterThanOrEqual", "value": 1000}}}
+			]`,
+			count: 3,
+		},
+		{
+			name:    "Queries should be invalid if a field of another database is used",
+			kind:    "MySQL",
+			query:   `{"database": "shop", "collection": "orders"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Queries should be invalid if the names are not unique",
+			kind:    "MariaDB",
+			query:   `[{"name": "users", "database": "shop"}, {"name": "users", "database": "crm"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Queries should be invalid if the row count is checked without a table",
+			kind:    "Postgres",
+			query:   `{"database": "shop", "rowCount": {"operator": "Equal", "value": 10}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Postgres queries should be valid if the table is checked without a schema",
+			kind:    "Postgres",
+			query:   `{"database": "shop", "table": "users", "rowCount": {"operator": "Equal", "value": 10}}`,
+			count:   1,
+			wantErr: false,
+		},
+		{
+			name:    "Queries should be invalid if the operator is not valid",
+			kind:    "Redis",
+			query:   `{"index": 0, "dbSize": {"operator": "Between", "value": 10}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Queries should be invalid if the sql query has no expected result",
+			kind:    "MSSQLServer",
+			query:   `{"database": "shop", "sql": {"statement": "SELECT 1"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Queries should be invalid if the kind is not supported",
+			kind:    "Deployment",
+			query:   `{"database": "shop"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			queries, err := DecodeQueries(test.kind, &runtime.RawExtension{Raw: []byte(test.query)})
+			if err == nil {
+				err = ValidateQueries(queries)
+			}
+			assert.Equal(t, test.wantErr, err != nil, err)
+			if !test.wantErr {
+				assert.Len(t, queries, test.count)
+			}
+		})
+	}
+}
+
+func TestMatchExpressionMatches(t *testing.T) {
+	expr := &MatchExpression{Operator: GreaterThanOrEqualOperator, Value: ptr.To(int64(10))}
+	assert.True(t, expr.Matches(10))
+	assert.False(t, expr.Matches(9))
+
+	expr.Operator = NotEqualOperator
+	assert.True(t, expr.Matches(9))
+	assert.False(t, expr.Matches(10))
+}
+
+func TestBackupVerificationSessionQueryResults(t *testing.T) {
+	session := &BackupVerificationSession{}
+	session.SetQueryResult(QueryResult{Name: "users", Phase: QueryFailed, Expected: "Equal 10", Actual: "9"})
+	session.SetQueryResult(QueryResult{Name: "orders", Phase: QueryPassed})
+	assert.True(t, session.HasFailedQuery())
+
+	session.SetQueryResult(QueryResult{Name: "users", Phase: QueryPassed, Expected: "Equal 10", Actual: "10"})
+	assert.Len(t, session.Status.QueryResults, 2)
+	assert.False(t, session.HasFailedQuery())
+}
+
+func TestPostgresQueryGetSchema(t *testing.T) {
+	assert.Equal(t, DefaultPostgresSchema, PostgresQuery{Table: "users"}.GetSchema())
+	assert.Equal(t, "sales", PostgresQuery{Schema: "sales", Table: "users"}.GetSchema())
+}
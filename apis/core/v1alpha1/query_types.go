@@ -18,6 +18,11 @@ package v1alpha1
 
 // MySQLQuery specifies query for MySQL database
 type MySQLQuery struct {
+	// Name specifies the name of the check. It is used to record the result of the check.
+	// The default name is "query-<index>".
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Database refers to the database name being checked for existence
 	Database string `json:"database,omitempty"`
 
@@ -28,10 +33,23 @@ type MySQLQuery struct {
 	// RowCount represents the number of row to be checked in the specified Table
 	// +optional
 	RowCount *MatchExpression `json:"rowCount,omitempty"`
+
+	// Checksum specifies the expected checksum of the specified Table
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// SQL specifies an arbitrary query returning a single numerical value that will be compared with the expected result
+	// +optional
+	SQL *ScalarQuery `json:"sql,omitempty"`
 }
 
 // MariaDBQuery specifies query for MariaDB database
 type MariaDBQuery struct {
+	// Name specifies the name of the check. It is used to record the result of the check.
+	// The default name is "query-<index>".
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Database refers to the database name being checked for existence
 	Database string `json:"database,omitempty"`
 
@@ -42,14 +60,28 @@ type MariaDBQuery struct {
 	// RowCount represents the number of row to be checked in the specified Table
 	// +optional
 	RowCount *MatchExpression `json:"rowCount,omitempty"`
+
+	// Checksum specifies the expected checksum of the specified Table
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// SQL specifies an arbitrary query returning a single numerical value that will be compared with the expected result
+	// +optional
+	SQL *ScalarQuery `json:"sql,omitempty"`
 }
 
 // PostgresQuery specifies query for Postgres database
 type PostgresQuery struct {
+	// Name specifies the name of the check. It is used to record the result of the check.
+	// The default name is "query-<index>".
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Database refers to the database name being checked for existence
 	Database string `json:"database,omitempty"`
 
-	// Schema refers to the schema name being checked for existence in specified Database
+	// Schema refers to the schema name being checked for existence in specified Database.
+	// The default schema is "public".
 	// +optional
 	Schema string `json:"schema,omitempty"`
 
@@ -60,10 +92,23 @@ type PostgresQuery struct {
 	// RowCount represents the number of row to be checked in the specified Table
 	// +optional
 	RowCount *MatchExpression `json:"rowCount,omitempty"`
+
+	// Checksum specifies the expected checksum of the specified Table
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// SQL specifies an arbitrary query returning a single numerical value that will be compared with the expected result
+	// +optional
+	SQL *ScalarQuery `json:"sql,omitempty"`
 }
 
 // MongoDBQuery specifies query for MongoDB database
 type MongoDBQuery struct {
+	// Name specifies the name of the check. It is used to record the result of the check.
+	// The default name is "query-<index>".
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Database refers to the database name being checked for existence
 	Database string `json:"database,omitempty"`
 
@@ -78,12 +123,22 @@ type MongoDBQuery struct {
 
 // ElasticsearchQuery specifies query for Elasticsearch database
 type ElasticsearchQuery struct {
+	// Name specifies the name of the check. It is used to record the result of the check.
+	// The default name is "query-<index>".
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Index refers to the index name being checked for existence
 	Index string `json:"index,omitempty"`
 }
 
 // RedisQuery specifies query for Redis database
 type RedisQuery struct {
+	// Name specifies the name of the check. It is used to record the result of the check.
+	// The default name is "query-<index>".
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Index refers to the database index being checked for existence
 	Index int `json:"index,omitempty"`
 
@@ -94,6 +149,11 @@ type RedisQuery struct {
 
 // SinglestoreQuery specifies query for Singlestore database
 type SinglestoreQuery struct {
+	// Name specifies the name of the check. It is used to record the result of the check.
+	// The default name is "query-<index>".
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Database refers to the database name being checked for existence
 	Database string `json:"database,omitempty"`
 
@@ -104,10 +164,23 @@ type SinglestoreQuery struct {
 	// RowCount represents the number of row to be checked in the specified Table
 	// +optional
 	RowCount *MatchExpression `json:"rowCount,omitempty"`
+
+	// Checksum specifies the expected checksum of the specified Table
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// SQL specifies an arbitrary query returning a single numerical value that will be compared with the expected result
+	// +optional
+	SQL *ScalarQuery `json:"sql,omitempty"`
 }
 
 // MSSQLServerQuery specifies query for MSSQLServer database
 type MSSQLServerQuery struct {
+	// Name specifies the name of the check. It is used to record the result of the check.
+	// The default name is "query-<index>".
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Database refers to the database name being checked for existence
 	Database string `json:"database,omitempty"`
 
@@ -122,6 +195,23 @@ type MSSQLServerQuery struct {
 	// RowCount represents the number of row to be checked in the specified Table
 	// +optional
 	RowCount *MatchExpression `json:"rowCount,omitempty"`
+
+	// Checksum specifies the expected checksum of the specified Table
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// SQL specifies an arbitrary query returning a single numerical value that will be compared with the expected result
+	// +optional
+	SQL *ScalarQuery `json:"sql,omitempty"`
+}
+
+// ScalarQuery specifies a query that returns a single numerical value
+type ScalarQuery struct {
+	// Statement specifies the query to run
+	Statement string `json:"statement,omitempty"`
+
+	// Result represents the expected result of the query
+	Result *MatchExpression `json:"result,omitempty"`
 }
 
 type MatchExpression struct {
@@ -17,13 +17,20 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
 	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
 
 	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/apis/storage/v1alpha1"
 	"kubestash.dev/apimachinery/crds"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	kmapi "kmodules.xyz/client-go/api/v1"
 	"kmodules.xyz/client-go/apiextensions"
 	cutil "kmodules.xyz/client-go/conditions"
@@ -37,7 +44,8 @@ func (RestoreSession) CustomResourceDefinition() *apiextensions.CustomResourceDe
 }
 
 func (rs *RestoreSession) CalculatePhase() RestorePhase {
-	if cutil.IsConditionFalse(rs.Status.Conditions, TypeMetricsPushed) {
+	if cutil.IsConditionFalse(rs.Status.Conditions, TypeMetricsPushed) ||
+		cutil.IsConditionFalse(rs.Status.Conditions, TypeSnapshotResolved) {
 		return RestoreFailed
 	}
 
@@ -45,15 +53,24 @@ func (rs *RestoreSession) CalculatePhase() RestorePhase {
 		return RestoreInvalid
 	}
 
+	if rs.Spec.DryRun {
+		return rs.getPreflightPhase()
+	}
+
 	if cutil.IsConditionTrue(rs.Status.Conditions, TypeMetricsPushed) &&
 		(cutil.IsConditionFalse(rs.Status.Conditions, TypePreRestoreHooksExecutionSucceeded) ||
 			cutil.IsConditionFalse(rs.Status.Conditions, TypePostRestoreHooksExecutionSucceeded) ||
 			cutil.IsConditionFalse(rs.Status.Conditions, TypeRestoreExecutorEnsured) ||
+			rs.Status.Hooks.HasUndoHookInPhase(HookExecutionFailed) ||
 			cutil.IsConditionTrue(rs.Status.Conditions, TypeRestoreIncomplete)) {
 		return RestoreFailed
 	}
 
 	componentsPhase := rs.getComponentsPhase()
+	if componentsPhase != RestorePending && rs.Status.Hooks.HasUndoHookInPhase(HookExecutionPending) {
+		// the session is not completed, and hence the final step is not executed, until the undo hooks are delivered
+		return RestoreRunning
+	}
 	if componentsPhase == RestorePending || rs.FinalStepExecuted() {
 		return componentsPhase
 	}
@@ -65,6 +82,89 @@ func (rs *RestoreSession) CalculatePhase() RestorePhase {
 	return RestoreRunning
 }
 
+// PreflightChecks returns the condition types of the checks that are run for a dry-run restore
+func PreflightChecks() []string {
+	return []string{
+		TypePreflightSnapshotReady,
+		TypePreflightEncryptionSecretValid,
+		TypePreflightTargetAvailable,
+		TypePreflightComponentsAvailable,
+		TypePreflightAddonTasksAvailable,
+		TypePreflightStorageCapacitySufficient,
+		TypePreflightPermissionsGranted,
+	}
+}
+
+func (rs *RestoreSession) getPreflightPhase() RestorePhase {
+	completed, skipped := 0, 0
+	for _, check := range PreflightChecks() {
+		if cutil.IsConditionFalse(rs.Status.Conditions, check) {
+			return RestoreFailed
+		}
+		if cutil.IsConditionTrue(rs.Status.Conditions, check) {
+			completed++
+		}
+		if _, cond := cutil.GetCondition(rs.Status.Conditions, check); cond != nil &&
+			cond.Status == metav1.ConditionUnknown && cond.Reason == ReasonPreflightCheckSkipped {
+			skipped++
+		}
+	}
+
+	switch {
+	case completed+skipped == 0:
+		return RestorePending
+	case completed+skipped < len(PreflightChecks()):
+		return RestoreRunning
+	case skipped > 0:
+		return RestorePreflightSkipped
+	default:
+		return RestoreSucceeded
+	}
+}
+
+// SetPreflightCheckCondition records the result of the given preflight check. A nil error means the check has passed.
+func (rs *RestoreSession) SetPreflightCheckCondition(check string, err error) {
+	newCond := kmapi.Condition{
+		Type:    kmapi.ConditionType(check),
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonPreflightCheckPassed,
+		Message: "Preflight check has passed.",
+	}
+	if err != nil {
+		newCond.Status = metav1.ConditionFalse
+		newCond.Reason = ReasonPreflightCheckFailed
+		newCond.Message = fmt.Sprintf("Preflight check has failed. Reason: %q", err.Error())
+	}
+	rs.Status.Conditions = cutil.SetCondition(rs.Status.Conditions, newCond)
+}
+
+// SetPreflightCheckSkipped records the given preflight check as skipped. A skipped check does not fail the dry-run,
+// but the dry-run is reported as PreflightSkipped instead of Succeeded.
+func (rs *RestoreSession) SetPreflightCheckSkipped(check, reason string) {
+	newCond := kmapi.Condition{
+		Type:    kmapi.ConditionType(check),
+		Status:  metav1.ConditionUnknown,
+		Reason:  ReasonPreflightCheckSkipped,
+		Message: fmt.Sprintf("Preflight check has been skipped. Reason: %q", reason),
+	}
+	rs.Status.Conditions = cutil.SetCondition(rs.Status.Conditions, newCond)
+}
+
+// GetMissingComponents returns the components requested in the DataSource that are not present in the given Snapshot
+func (rs *RestoreSession) GetMissingComponents(snap *v1alpha1.Snapshot) []string {
+	if rs.Spec.DataSource == nil {
+		return nil
+	}
+
+	var missing []string
+	for _, comp := range rs.Spec.DataSource.Components {
+		if _, ok := snap.Status.Components[comp]; !ok {
+			missing = append(missing, comp)
+		}
+	}
+	return missing
+}
+
 func (rs *RestoreSession) AllComponentsCompleted() bool {
 	phase := rs.getComponentsPhase()
 	return phase == RestoreSucceeded || phase == RestoreFailed
@@ -214,6 +314,126 @@ func (rs *RestoreSession) GetTargetObjectRef(snap *v1alpha1.Snapshot) *kmapi.Obj
 	return rs.getTargetRef(snap.Spec.AppRef)
 }
 
+// ResolveSnapshot returns the Snapshot that should be restored according to the DataSource.
+// Only the Snapshots of the Repository specified in the DataSource are taken into account.
+func (rs *RestoreSession) ResolveSnapshot(snapshots []v1alpha1.Snapshot) (*v1alpha1.Snapshot, error) {
+	ds := rs.Spec.DataSource
+	if ds == nil {
+		return nil, fmt.Errorf("dataSource is not specified")
+	}
+
+	namespace := rs.GetDataSourceNamespace()
+	var candidates []v1alpha1.Snapshot
+	for _, snap := range snapshots {
+		if snap.Namespace != namespace {
+			continue
+		}
+		if ds.Repository != "" && snap.Spec.Repository != ds.Repository {
+			continue
+		}
+		candidates = append(candidates, snap)
+	}
+
+	selector := ds.GetSnapshotSelector()
+	if selector == nil {
+		for i := range candidates {
+			if candidates[i].Name == ds.Snapshot {
+				return &candidates[i], nil
+			}
+		}
+		return nil, fmt.Errorf("snapshot %s/%s not found in repository %q", namespace, ds.Snapshot, ds.Repository)
+	}
+	return selector.SelectSnapshot(candidates)
+}
+
+// GetSnapshotSelector returns the selector equivalent to the DataSource. It returns nil
+// if the DataSource refers to a Snapshot by its name.
+func (ds *RestoreDataSource) GetSnapshotSelector() *SnapshotSelector {
+	if ds.SnapshotSelector != nil {
+		return ds.SnapshotSelector
+	}
+
+	switch ds.Snapshot {
+	case SnapshotLatest:
+		return &SnapshotSelector{}
+	case SnapshotLatestSucceeded:
+		return &SnapshotSelector{Phase: v1alpha1.SnapshotSucceeded}
+	case SnapshotLatestVerified:
+		return &SnapshotSelector{VerificationStatus: v1alpha1.SnapshotVerified}
+	default:
+		return nil
+	}
+}
+
+// SelectSnapshot returns the Snapshot that matches the selector from the given Snapshots.
+func (s *SnapshotSelector) SelectSnapshot(snapshots []v1alpha1.Snapshot) (*v1alpha1.Snapshot, error) {
+	selector := labels.Everything()
+	if s.LabelSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(s.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labelSelector: %w", err)
+		}
+	}
+
+	var matched []v1alpha1.Snapshot
+	for _, snap := range snapshots {
+		if s.Phase != "" && snap.Status.Phase != s.Phase {
+			continue
+		}
+		if s.VerificationStatus != "" && snap.Status.VerificationStatus != s.VerificationStatus {
+			continue
+		}
+		if s.Before != nil && getSnapshotTime(snap).After(s.Before.Time) {
+			continue
+		}
+		if !selector.Matches(labels.Set(snap.Labels)) {
+			continue
+		}
+		matched = append(matched, snap)
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no snapshot matched the selector")
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		ti, tj := getSnapshotTime(matched[i]), getSnapshotTime(matched[j])
+		if s.SortOrder == SnapshotSortOrderOldest {
+			return ti.Before(&tj)
+		}
+		return tj.Before(&ti)
+	})
+	return &matched[0], nil
+}
+
+func getSnapshotTime(snap v1alpha1.Snapshot) metav1.Time {
+	if snap.Status.SnapshotTime != nil {
+		return *snap.Status.SnapshotTime
+	}
+	return snap.CreationTimestamp
+}
+
+func (rs *RestoreSession) SetSnapshotResolvedConditionToTrue(snapshot string) {
+	rs.Status.Snapshot = snapshot
+	newCond := kmapi.Condition{
+		Type:    TypeSnapshotResolved,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonSuccessfullyResolvedSnapshot,
+		Message: fmt.Sprintf("Snapshot %q has been selected for restore.", snapshot),
+	}
+	rs.Status.Conditions = cutil.SetCondition(rs.Status.Conditions, newCond)
+}
+
+func (rs *RestoreSession) SetSnapshotResolvedConditionToFalse(err error) {
+	newCond := kmapi.Condition{
+		Type:    TypeSnapshotResolved,
+		Status:  metav1.ConditionFalse,
+		Reason:  ReasonFailedToResolveSnapshot,
+		Message: fmt.Sprintf("Failed to resolve the Snapshot to restore. Reason: %q", err.Error()),
+	}
+	rs.Status.Conditions = cutil.SetCondition(rs.Status.Conditions, newCond)
+}
+
 func (rs *RestoreSession) IsApplicationLevelRestore() bool {
 	tasks := map[string]bool{}
 	for _, task := range rs.Spec.Addon.Tasks {
@@ -289,3 +509,91 @@ func (rs *RestoreSession) getTargetRef(appRef kmapi.TypedObjectReference) *kmapi
 
 	return targetRef
 }
+
+// GetPathFilter returns the path filter of the given component. The filter without any component
+// is returned if the component has no filter of its own. It returns nil if no filter applies.
+func (rs *RestoreSession) GetPathFilter(component string) *ComponentPathFilter {
+	var fallback *ComponentPathFilter
+	for i := range rs.Spec.PathFilters {
+		f := &rs.Spec.PathFilters[i]
+		if f.Component == component {
+			return f
+		}
+		if f.Component == "" {
+			fallback = f
+		}
+	}
+	return fallback
+}
+
+// SetPathFilterParams passes the path filter of the given component to the given restore task through the
+// `pathFilter` parameter. The filter is encoded as a JSON string, as the parameters of the addons are string valued.
+// The other parameters of the task are kept as they are.
+func (rs *RestoreSession) SetPathFilterParams(task *TaskReference, component string) error {
+	filter := rs.GetPathFilter(component)
+	if filter == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(filter)
+	if err != nil {
+		return fmt.Errorf("failed to encode path filter of component %q: %w", component, err)
+	}
+
+	params := map[string]any{}
+	if task.Params != nil && len(task.Params.Raw) > 0 {
+		if err := json.Unmarshal(task.Params.Raw, &params); err != nil {
+			return fmt.Errorf("failed to decode params of task %q: %w", task.Name, err)
+		}
+	}
+	params[apis.ParamKeyPathFilter] = string(encoded)
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode params of task %q: %w", task.Name, err)
+	}
+	task.Params = &runtime.RawExtension{Raw: raw}
+	return nil
+}
+
+// SetRestoredFiles records the files that have been restored for the given component
+func (rs *RestoreSession) SetRestoredFiles(component string, files []string) {
+	if rs.Status.Components == nil {
+		rs.Status.Components = map[string]ComponentRestoreStatus{}
+	}
+
+	comp := rs.Status.Components[component]
+	comp.TotalRestoredFiles = int64(len(files))
+	if len(files) > apis.MaxRestoredFileListSize {
+		files = files[:apis.MaxRestoredFileListSize]
+	}
+	comp.RestoredFiles = files
+	rs.Status.Components[component] = comp
+}
+
+// Matches returns whether the given path should be restored according to the filter.
+// A pattern that matches a directory matches everything under it as well.
+func (f *ComponentPathFilter) Matches(p string) bool {
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if slices.ContainsFunc(f.Exclude, func(pattern string) bool { return matchPath(pattern, p) }) {
+		return false
+	}
+	return len(f.Include) == 0 || slices.ContainsFunc(f.Include, func(pattern string) bool { return matchPath(pattern, p) })
+}
+
+func matchPath(pattern, p string) bool {
+	pattern = strings.TrimPrefix(path.Clean("/"+pattern), "/")
+	for ; p != "." && p != ""; p = path.Dir(p) {
+		if matched, _ := path.Match(pattern, p); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RemapPath returns the path where the given path should be restored into
+func (f *ComponentPathFilter) RemapPath(p string) string {
+	if f.TargetPath == "" {
+		return p
+	}
+	return path.Join(f.TargetPath, p)
+}
@@ -17,10 +17,17 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	kmapi "kmodules.xyz/client-go/api/v1"
 	cutil "kmodules.xyz/client-go/conditions"
 )
@@ -300,6 +307,193 @@ func TestRestoreSessionPhaseIsRunningIfPostRestoreHooksNotExecuted(test *testing
 	assert.Equal(test, RestoreRunning, rs.CalculatePhase())
 }
 
+func TestRestoreSessionPhaseIsFailedIfSnapshotResolvedConditionIsFalse(t *testing.T) {
+	rs := sampleRestoreSession(func(r *RestoreSession) {
+		r.SetSnapshotResolvedConditionToFalse(assert.AnError)
+	})
+
+	assert.Equal(t, RestoreFailed, rs.CalculatePhase())
+}
+
+func TestRestoreSessionPhaseBasedOnPreflightChecks(t *testing.T) {
+	tests := []struct {
+		name          string
+		passed        []string
+		failed        []string
+		skipped       []string
+		expectedPhase RestorePhase
+	}{
+		{
+			name:          "Dry-run RestoreSession should be Pending if no preflight check has been run",
+			expectedPhase: RestorePending,
+		},
+		{
+			name:          "Dry-run RestoreSession should be Running if some preflight checks have not been run",
+			passed:        []string{TypePreflightSnapshotReady, TypePreflightTargetAvailable},
+			expectedPhase: RestoreRunning,
+		},
+		{
+			name:          "Dry-run RestoreSession should be Failed if any preflight check has failed",
+			passed:        []string{TypePreflightSnapshotReady},
+			failed:        []string{TypePreflightComponentsAvailable},
+			expectedPhase: RestoreFailed,
+		},
+		{
+			name:          "Dry-run RestoreSession should be Succeeded if all preflight checks have passed",
+			passed:        PreflightChecks(),
+			expectedPhase: RestoreSucceeded,
+		},
+		{
+			name:          "Dry-run RestoreSession should be PreflightSkipped if any preflight check has been skipped",
+			passed:        PreflightChecks()[:len(PreflightChecks())-1],
+			skipped:       PreflightChecks()[len(PreflightChecks())-1:],
+			expectedPhase: RestorePreflightSkipped,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rs := sampleRestoreSession(func(r *RestoreSession) {
+				r.Spec.DryRun = true
+			})
+			for _, check := range test.passed {
+				rs.SetPreflightCheckCondition(check, nil)
+			}
+			for _, check := range test.failed {
+				rs.SetPreflightCheckCondition(check, assert.AnError)
+			}
+			for _, check := range test.skipped {
+				rs.SetPreflightCheckSkipped(check, "not applicable")
+			}
+			assert.Equal(t, test.expectedPhase, rs.CalculatePhase())
+		})
+	}
+}
+
+func TestGetMissingComponents(t *testing.T) {
+	snap := &storageapi.Snapshot{
+		Status: storageapi.SnapshotStatus{
+			Components: map[string]storageapi.Component{
+				"dump":     {},
+				"manifest": {},
+			},
+		},
+	}
+
+	rs := sampleRestoreSession(func(r *RestoreSession) {
+		r.Spec.DataSource = &RestoreDataSource{Components: []string{"dump", "wal", "manifest", "pod-0"}}
+	})
+	assert.Equal(t, []string{"wal", "pod-0"}, rs.GetMissingComponents(snap))
+
+	rs.Spec.DataSource.Components = nil
+	assert.Empty(t, rs.GetMissingComponents(snap))
+}
+
+func TestResolveSnapshot(t *testing.T) {
+	now := time.Now()
+	snapshots := []storageapi.Snapshot{
+		sampleSnapshot("gcs-repo-1", "gcs-repo", now.Add(-4*time.Hour), storageapi.SnapshotSucceeded, storageapi.SnapshotVerified, nil),
+		sampleSnapshot("s3-repo-1", "s3-repo", now.Add(-3*time.Hour), storageapi.SnapshotSucceeded, storageapi.SnapshotVerified, map[string]string{"tier": "gold"}),
+		sampleSnapshot("s3-repo-2", "s3-repo", now.Add(-2*time.Hour), storageapi.SnapshotSucceeded, storageapi.SnapshotNotVerified, nil),
+		sampleSnapshot("s3-repo-3", "s3-repo", now.Add(-1*time.Hour), storageapi.SnapshotFailed, "", nil),
+	}
+
+	tests := []struct {
+		name             string
+		dataSource       *RestoreDataSource
+		expectedSnapshot string
+		expectErr        bool
+	}{
+		{
+			name:             "Exact Snapshot name should be resolved to the same Snapshot",
+			dataSource:       &RestoreDataSource{Repository: "s3-repo", Snapshot: "s3-repo-2"},
+			expectedSnapshot: "s3-repo-2",
+		},
+		{
+			name:       "Exact Snapshot name should fail if the Snapshot belongs to a different Repository",
+			dataSource: &RestoreDataSource{Repository: "s3-repo", Snapshot: "gcs-repo-1"},
+			expectErr:  true,
+		},
+		{
+			name:             "latest should be resolved to the most recent Snapshot",
+			dataSource:       &RestoreDataSource{Repository: "s3-repo", Snapshot: SnapshotLatest},
+			expectedSnapshot: "s3-repo-3",
+		},
+		{
+			name:             "latest-succeeded should be resolved to the most recent Succeeded Snapshot",
+			dataSource:       &RestoreDataSource{Repository: "s3-repo", Snapshot: SnapshotLatestSucceeded},
+			expectedSnapshot: "s3-repo-2",
+		},
+		{
+			name:             "latest-verified should be resolved to the most recent Verified Snapshot",
+			dataSource:       &RestoreDataSource{Repository: "s3-repo", Snapshot: SnapshotLatestVerified},
+			expectedSnapshot: "s3-repo-1",
+		},
+		{
+			name: "Selector should be resolved to the most recent Snapshot before the given time",
+			dataSource: &RestoreDataSource{Repository: "s3-repo", SnapshotSelector: &SnapshotSelector{
+				Before: &metav1.Time{Time: now.Add(-90 * time.Minute)},
+			}},
+			expectedSnapshot: "s3-repo-2",
+		},
+		{
+			name: "Selector should be resolved to the oldest Snapshot if the sort order is Oldest",
+			dataSource: &RestoreDataSource{Repository: "s3-repo", SnapshotSelector: &SnapshotSelector{
+				Phase:     storageapi.SnapshotSucceeded,
+				SortOrder: SnapshotSortOrderOldest,
+			}},
+			expectedSnapshot: "s3-repo-1",
+		},
+		{
+			name: "Selector should be resolved to the Snapshot with matching labels",
+			dataSource: &RestoreDataSource{Repository: "s3-repo", SnapshotSelector: &SnapshotSelector{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}},
+			}},
+			expectedSnapshot: "s3-repo-1",
+		},
+		{
+			name: "Selector should fail if no Snapshot matches",
+			dataSource: &RestoreDataSource{Repository: "s3-repo", SnapshotSelector: &SnapshotSelector{
+				Before: &metav1.Time{Time: now.Add(-5 * time.Hour)},
+			}},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rs := sampleRestoreSession(func(r *RestoreSession) {
+				r.Spec.DataSource = test.dataSource
+			})
+			snap, err := rs.ResolveSnapshot(snapshots)
+			if test.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedSnapshot, snap.Name)
+		})
+	}
+}
+
+func sampleSnapshot(name, repo string, snapshotTime time.Time, phase storageapi.SnapshotPhase, verification storageapi.VerificationStatus, labels map[string]string) storageapi.Snapshot {
+	return storageapi.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "demo",
+			Labels:    labels,
+		},
+		Spec: storageapi.SnapshotSpec{
+			Repository: repo,
+		},
+		Status: storageapi.SnapshotStatus{
+			Phase:              phase,
+			VerificationStatus: verification,
+			SnapshotTime:       &metav1.Time{Time: snapshotTime},
+		},
+	}
+}
+
 func sampleRestoreSession(transformFuncs ...func(*RestoreSession)) *RestoreSession {
 	rs := &RestoreSession{
 		ObjectMeta: metav1.ObjectMeta{
@@ -365,3 +559,76 @@ func setPostRestoreHooksExecutionSucceededConditionToTrue(rs *RestoreSession) {
 	}
 	rs.Status.Conditions = cutil.SetCondition(rs.Status.Conditions, newCond)
 }
+
+func TestPathFilterMatches(t *testing.T) {
+	f := &ComponentPathFilter{
+		Include: []string{"data/logs", "/etc/*.conf"},
+		Exclude: []string{"data/logs/*.tmp"},
+	}
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "data/logs", expected: true},
+		{path: "/data/logs/app.log", expected: true},
+		{path: "data/logs/app.tmp", expected: false},
+		{path: "etc/nginx.conf", expected: true},
+		{path: "etc/nginx/nginx.conf", expected: false},
+		{path: "data/db", expected: false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, f.Matches(test.path), test.path)
+	}
+
+	assert.True(t, (&ComponentPathFilter{Exclude: []string{"tmp"}}).Matches("data/app.log"))
+	assert.Equal(t, "data/logs/app.log", f.RemapPath("data/logs/app.log"))
+	f.TargetPath = "/restore"
+	assert.Equal(t, "/restore/data/logs/app.log", f.RemapPath("data/logs/app.log"))
+}
+
+func TestPathFilterParams(t *testing.T) {
+	rs := sampleRestoreSession(func(r *RestoreSession) {
+		r.Spec.PathFilters = []ComponentPathFilter{
+			{Include: []string{"data"}},
+			{Component: "dump", Include: []string{"sales/orders"}, TargetPath: "/restore"},
+		}
+	})
+	assert.Equal(t, "/restore", rs.GetPathFilter("dump").TargetPath)
+	assert.Equal(t, []string{"data"}, rs.GetPathFilter("pod-0").Include)
+
+	task := &TaskReference{Name: "logical-backup-restore", Params: &runtime.RawExtension{Raw: []byte(`{"args":"--single-transaction"}`)}}
+	assert.NoError(t, rs.SetPathFilterParams(task, "dump"))
+	params := map[string]string{}
+	assert.NoError(t, json.Unmarshal(task.Params.Raw, &params))
+	assert.Equal(t, "--single-transaction", params["args"])
+	assert.JSONEq(t, `{"component": "dump", "include": ["sales/orders"], "targetPath": "/restore"}`, params[apis.ParamKeyPathFilter])
+
+	task = &TaskReference{Name: "logical-backup-restore"}
+	assert.NoError(t, rs.SetPathFilterParams(task, "pod-0"))
+	assert.NoError(t, json.Unmarshal(task.Params.Raw, &params))
+	assert.JSONEq(t, `{"include": ["data"]}`, params[apis.ParamKeyPathFilter])
+
+	rs.Spec.PathFilters = nil
+	assert.Nil(t, rs.GetPathFilter("dump"))
+	task = &TaskReference{Name: "logical-backup-restore"}
+	assert.NoError(t, rs.SetPathFilterParams(task, "dump"))
+	assert.Nil(t, task.Params)
+}
+
+func TestSetRestoredFiles(t *testing.T) {
+	rs := sampleRestoreSession(func(r *RestoreSession) {
+		r.Status.Components = map[string]ComponentRestoreStatus{"dump": {Phase: RestoreSucceeded}}
+	})
+
+	files := make([]string, apis.MaxRestoredFileListSize+5)
+	for i := range files {
+		files[i] = fmt.Sprintf("data/file-%d", i)
+	}
+	rs.SetRestoredFiles("dump", files)
+
+	comp := rs.Status.Components["dump"]
+	assert.Equal(t, RestoreSucceeded, comp.Phase)
+	assert.Len(t, comp.RestoredFiles, apis.MaxRestoredFileListSize)
+	assert.Equal(t, int64(len(files)), comp.TotalRestoredFiles)
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"kubestash.dev/apimachinery/apis"
+	storage "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kmapi "kmodules.xyz/client-go/api/v1"
@@ -70,6 +73,39 @@ type RestoreSessionSpec struct {
 	// ManifestOptions provide options to select particular manifest object to restore
 	// +optional
 	ManifestOptions *ManifestRestoreOptions `json:"manifestOptions,omitempty"`
+
+	// DryRun specifies whether to only run the preflight checks instead of restoring the data.
+	// The result of each check is reported as a condition of the RestoreSession.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// PathFilters specifies the paths that should be restored from the individual components.
+	// The filters are passed to the restore tasks through the `pathFilters` parameter.
+	// +optional
+	PathFilters []ComponentPathFilter `json:"pathFilters,omitempty"`
+}
+
+// ComponentPathFilter specifies the paths that should be restored from a component
+type ComponentPathFilter struct {
+	// Component specifies the name of the component this filter applies to.
+	// If it is empty, the filter applies to all the components that have no filter of their own.
+	// +optional
+	Component string `json:"component,omitempty"`
+
+	// Include specifies the glob patterns of the paths to restore. Everything is restored if it is empty.
+	// For the database dumps, the patterns are matched against the tables instead (i.e. "<database>/<table>").
+	// +optional
+	Include []string `json:"include,omitempty"`
+
+	// Exclude specifies the glob patterns of the paths that should not be restored.
+	// It takes precedence over the Include patterns.
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+
+	// TargetPath specifies the absolute path of the directory where the paths of the component should be restored.
+	// The paths are restored into their original location if it is empty.
+	// +optional
+	TargetPath string `json:"targetPath,omitempty"`
 }
 
 type ManifestRestoreOptions struct {
@@ -296,10 +332,19 @@ type RestoreDataSource struct {
 	Repository string `json:"repository,omitempty"`
 
 	// Snapshot specifies the Snapshot name that will be restored.
+	// You can also use one of the following special values to let KubeStash pick the Snapshot from the Repository:
+	// - "latest": The most recent Snapshot of the Repository.
+	// - "latest-succeeded": The most recent Snapshot that has Succeeded.
+	// - "latest-verified": The most recent Snapshot that has been Verified.
 	// If you want to use Point-In-Time recovery option, don't specify this field. Specify `pitr` field instead.
 	// +optional
 	Snapshot string `json:"snapshot,omitempty"`
 
+	// SnapshotSelector specifies the criteria to select the Snapshot from the Repository that will be restored.
+	// Don't specify the `snapshot` field if you use this field.
+	// +optional
+	SnapshotSelector *SnapshotSelector `json:"snapshotSelector,omitempty"`
+
 	// PITR stands for Point-In-Time Recovery. You can provide a target time instead of specifying a particular Snapshot.
 	// KubeStash will automatically find the latest Snapshot that satisfies the targeted time and restore it.
 	// +optional
@@ -317,6 +362,46 @@ type RestoreDataSource struct {
 	EncryptionSecret *kmapi.ObjectReference `json:"encryptionSecret,omitempty"`
 }
 
+const (
+	SnapshotLatest          = "latest"
+	SnapshotLatestSucceeded = "latest-succeeded"
+	SnapshotLatestVerified  = "latest-verified"
+)
+
+// SnapshotSelector specifies the criteria to select a Snapshot from the Repository
+type SnapshotSelector struct {
+	// Before selects the Snapshots that have been taken at or before this time.
+	// +optional
+	Before *metav1.Time `json:"before,omitempty"`
+
+	// Phase selects the Snapshots that are in this phase.
+	// +optional
+	Phase storage.SnapshotPhase `json:"phase,omitempty"`
+
+	// VerificationStatus selects the Snapshots that have this verification status.
+	// +optional
+	VerificationStatus storage.VerificationStatus `json:"verificationStatus,omitempty"`
+
+	// LabelSelector selects the Snapshots whose labels match this selector.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// SortOrder specifies which of the selected Snapshots will be restored.
+	// "Newest" picks the most recent Snapshot and "Oldest" picks the earliest one.
+	// +kubebuilder:default=Newest
+	// +optional
+	SortOrder SnapshotSortOrder `json:"sortOrder,omitempty"`
+}
+
+// SnapshotSortOrder specifies the order in which the selected Snapshots are sorted
+// +kubebuilder:validation:Enum=Newest;Oldest
+type SnapshotSortOrder string
+
+const (
+	SnapshotSortOrderNewest SnapshotSortOrder = "Newest"
+	SnapshotSortOrderOldest SnapshotSortOrder = "Oldest"
+)
+
 // PITR specifies the target time and behavior of Point-In-Time Recovery
 type PITR struct {
 	// TargetTime specifies the desired date and time at which you want to roll back your application data
@@ -349,6 +434,10 @@ type RestoreSessionStatus struct {
 	// +optional
 	TargetFound *bool `json:"targetFound,omitempty"`
 
+	// Snapshot specifies the name of the Snapshot that has been resolved from the DataSource for this restore
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
 	// Duration specifies the total time taken to complete the restore process
 	// +optional
 	Duration string `json:"duration,omitempty"`
@@ -377,13 +466,23 @@ type RestoreSessionStatus struct {
 
 	// PausedBackups represents the list of backups that have been paused before restore.
 	// +optional
-	PausedBackups []kmapi.TypedObjectReference `json:"pausedBackups,omitempty"`
+	PausedBackups []PausedBackup `json:"pausedBackups,omitempty"`
 
 	// Conditions specifies a list of conditions related to this restore session
 	// +optional
 	Conditions []kmapi.Condition `json:"conditions,omitempty"`
 }
 
+// PausedBackup specifies a backup invoker that has been paused by the RestoreSession
+type PausedBackup struct {
+	kmapi.TypedObjectReference `json:",inline"`
+
+	// OriginalPauseInfo specifies the pause details of the backup invoker before the restore paused it.
+	// It is used to restore the original state after the restore completes. Nil means the invoker was not paused.
+	// +optional
+	OriginalPauseInfo *apis.PauseInfo `json:"originalPauseInfo,omitempty"`
+}
+
 // RestoreProgress specifies the progress of the Restic restore
 type RestoreProgress struct {
 	// SecondsElapsed represents the seconds elapsed during the restore
@@ -416,7 +515,7 @@ type RestoreProgress struct {
 }
 
 // RestorePhase represents the current state of the restore process
-// +kubebuilder:validation:Enum=Pending;Running;Failed;Succeeded;Invalid;Unknown
+// +kubebuilder:validation:Enum=Pending;Running;Failed;Succeeded;Invalid;Unknown;PreflightSkipped
 type RestorePhase string
 
 const (
@@ -426,6 +525,8 @@ const (
 	RestoreSucceeded    RestorePhase = "Succeeded"
 	RestoreInvalid      RestorePhase = "Invalid"
 	RestorePhaseUnknown RestorePhase = "Unknown"
+	// RestorePreflightSkipped represents a dry-run whose preflight checks have passed except the skipped ones
+	RestorePreflightSkipped RestorePhase = "PreflightSkipped"
 )
 
 // ComponentRestoreStatus represents the restore status of individual components
@@ -445,6 +546,15 @@ type ComponentRestoreStatus struct {
 	// Error specifies the reason in case of restore failure for the component
 	// +optional
 	Error string `json:"error,omitempty"`
+
+	// RestoredFiles specifies the files that have been restored for this component when path filters are used.
+	// Only the first few files are recorded. The total number is recorded in TotalRestoredFiles.
+	// +optional
+	RestoredFiles []string `json:"restoredFiles,omitempty"`
+
+	// TotalRestoredFiles specifies the total number of files that have been restored for this component
+	// +optional
+	TotalRestoredFiles int64 `json:"totalRestoredFiles,omitempty"`
 }
 
 const (
@@ -465,6 +575,22 @@ const (
 
 	TypeRestoreIncomplete                           = "RestoreIncomplete"
 	ReasonRestoreExecutorTerminatedBeforeCompletion = "RestoreExecutorTerminatedBeforeCompletion"
+
+	TypeSnapshotResolved               = "SnapshotResolved"
+	ReasonSuccessfullyResolvedSnapshot = "SuccessfullyResolvedSnapshot"
+	ReasonFailedToResolveSnapshot      = "FailedToResolveSnapshot"
+
+	// Preflight checks of a dry-run restore
+	TypePreflightSnapshotReady             = "PreflightSnapshotReady"
+	TypePreflightEncryptionSecretValid     = "PreflightEncryptionSecretValid"
+	TypePreflightTargetAvailable           = "PreflightTargetAvailable"
+	TypePreflightComponentsAvailable       = "PreflightComponentsAvailable"
+	TypePreflightAddonTasksAvailable       = "PreflightAddonTasksAvailable"
+	TypePreflightStorageCapacitySufficient = "PreflightStorageCapacitySufficient"
+	TypePreflightPermissionsGranted        = "PreflightPermissionsGranted"
+	ReasonPreflightCheckPassed             = "PreflightCheckPassed"
+	ReasonPreflightCheckFailed             = "PreflightCheckFailed"
+	ReasonPreflightCheckSkipped            = "PreflightCheckSkipped"
 )
 
 //+kubebuilder:object:root=true
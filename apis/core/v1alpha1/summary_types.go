@@ -30,6 +30,8 @@ type Summary struct {
 	Invoker *kmapi.TypedObjectReference `json:"invoker,omitempty"`
 	// Target specifies the target information that has been backed up /restored in this session
 	Target *kmapi.TypedObjectReference `json:"target,omitempty"`
+	// TriggerSource specifies the event that triggered the backup, if it was not triggered by the scheduler or manually
+	TriggerSource *TriggerSource `json:"triggerSource,omitempty"`
 	// Status specifies the backup/restore status for the respective target
 	Status TargetStatus `json:"status,omitempty"`
 }
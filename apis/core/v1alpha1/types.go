@@ -143,6 +143,14 @@ type HookInfo struct {
 	// Use this field only for `Function` type hook executor.
 	// +optional
 	RuntimeSettings *ofst.RuntimeSettings `json:"runtimeSettings,omitempty"`
+
+	// UndoHook specifies the name of a post hook that undoes the effect of this pre hook,
+	// i.e. a hook that unfreezes the filesystem frozen by this hook.
+	// Once this hook has started, KubeStash executes the undo hook regardless of its execution policy,
+	// even if the backup/restore executor was terminated, timed out or the operator was restarted.
+	// Use this field only for pre hooks.
+	// +optional
+	UndoHook string `json:"undoHook,omitempty"`
 }
 
 // HookStatus represents the status of the hooks
@@ -154,6 +162,36 @@ type HookStatus struct {
 	// PostHooks represents the post-restore hook execution status
 	// +optional
 	PostHooks []HookExecutionStatus `json:"postHooks,omitempty"`
+
+	// UndoHooks represents the delivery state of the undo hooks of the executed pre hooks.
+	// An undo hook is recorded before its pre hook is executed, so that it is delivered even after a failure.
+	// +optional
+	UndoHooks []UndoHookStatus `json:"undoHooks,omitempty"`
+}
+
+// UndoHookStatus represents the delivery state of an undo hook
+type UndoHookStatus struct {
+	// Name indicates the name of the undo hook
+	Name string `json:"name"`
+
+	// PreHook indicates the name of the pre hook this undo hook belongs to
+	PreHook string `json:"preHook"`
+
+	// Phase represents the delivery state of the undo hook
+	// +optional
+	Phase HookExecutionPhase `json:"phase,omitempty"`
+
+	// Attempts specifies how many times the undo hook has been executed
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// LastAttemptTime specifies when the undo hook was executed last time
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// Error specifies the reason if the last attempt failed
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // HookExecutionPolicy specifies when to execute the hook.
@@ -174,6 +212,62 @@ type HookExecutionStatus struct {
 	// Phase represents the hook execution phase
 	// +optional
 	Phase HookExecutionPhase `json:"phase,omitempty"`
+
+	// Attempts specifies how many times the hook has been executed, including the retries
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// Duration specifies the time required to execute the hook
+	// +optional
+	Duration string `json:"duration,omitempty"`
+
+	// DurationSeconds specifies the time required to execute the hook in seconds
+	// +optional
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+
+	// Error specifies the reason if the hook execution failed
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// Stdout specifies the tail of the standard output of the hook, up to 1 KiB
+	// +optional
+	Stdout string `json:"stdout,omitempty"`
+
+	// Outputs specifies the named values emitted by the hook.
+	// They can be used as variables in the following hooks and tasks, see HookOutputVariableName.
+	// +optional
+	Outputs []HookOutput `json:"outputs,omitempty"`
+
+	// Pods specifies the execution result of the hook in each of the pods for pod type executor
+	// +optional
+	Pods []PodHookExecutionStatus `json:"pods,omitempty"`
+}
+
+// PodHookExecutionStatus represents the execution result of a hook in a pod
+type PodHookExecutionStatus struct {
+	// Name specifies the name of the pod
+	Name string `json:"name"`
+
+	// Container specifies the name of the container where the hook has been executed
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// Phase represents the hook execution phase in the pod
+	// +optional
+	Phase HookExecutionPhase `json:"phase,omitempty"`
+
+	// Error specifies the reason if the hook execution failed in the pod
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// HookOutput specifies a named value emitted by a hook
+type HookOutput struct {
+	// Name specifies the name of the output
+	Name string `json:"name"`
+
+	// Value specifies the value of the output
+	Value string `json:"value"`
 }
 
 // HookExecutionPhase specifies the state of the hook execution
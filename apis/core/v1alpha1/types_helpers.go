@@ -0,0 +1,234 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetPreHookStatus adds or updates the status of a pre-hook
+func (s *HookStatus) SetPreHookStatus(status HookExecutionStatus) {
+	s.PreHooks = upsertHookStatus(s.PreHooks, status)
+}
+
+// SetPostHookStatus adds or updates the status of a post-hook
+func (s *HookStatus) SetPostHookStatus(status HookExecutionStatus) {
+	s.PostHooks = upsertHookStatus(s.PostHooks, status)
+}
+
+func upsertHookStatus(hooks []HookExecutionStatus, status HookExecutionStatus) []HookExecutionStatus {
+	for i := range hooks {
+		if hooks[i].Name == status.Name {
+			hooks[i] = status
+			return hooks
+		}
+	}
+	return append(hooks, status)
+}
+
+// GetOutputVariables returns the outputs of the succeeded hooks as variables that can be
+// resolved using pkg.ResolveWithInputs, i.e. "${HOOK_PRE_FLUSH_LOGS__BINLOG_POSITION}".
+func (s HookStatus) GetOutputVariables() map[string]string {
+	vars := make(map[string]string)
+	for stage, hooks := range map[string][]HookExecutionStatus{
+		apis.HookStagePre:  s.PreHooks,
+		apis.HookStagePost: s.PostHooks,
+	} {
+		for _, hook := range hooks {
+			if hook.Phase != HookExecutionSucceeded {
+				continue
+			}
+			for _, out := range hook.Outputs {
+				vars[HookOutputVariableName(stage, hook.Name, out.Name)] = out.Value
+			}
+		}
+	}
+	return vars
+}
+
+// HookOutputVariableName returns the name of the variable holding the given output of the given hook,
+// i.e. "HOOK_PRE_FLUSH_LOGS__BINLOG_POSITION" for the output "binlog-position" of the pre hook "flush-logs".
+// The hook and the output names are normalized using ToVariableName and joined with "__", which can not
+// appear in a normalized name. So, the outputs of the different hooks never share a variable.
+func HookOutputVariableName(stage, hook, output string) string {
+	return apis.HookOutputVariablePrefix + stage + "_" + ToVariableName(hook) + "__" + ToVariableName(output)
+}
+
+// ValidateHookNames returns an error if any two of the given hooks of the same stage have the same
+// output variable name, i.e. "flush-logs" and "flush_logs", as the outputs of one would overwrite the other.
+// A pre hook and a post hook can have the same name, as their outputs are stored in different variables.
+func ValidateHookNames(preHooks, postHooks []HookInfo) error {
+	for _, hooks := range [][]HookInfo{preHooks, postHooks} {
+		names := make(map[string]string)
+		for _, hook := range hooks {
+			name := ToVariableName(hook.Name)
+			if other, ok := names[name]; ok {
+				if other == hook.Name {
+					return fmt.Errorf("hook name %q is used more than once", hook.Name)
+				}
+				return fmt.Errorf("hook names %q and %q conflict with each other in the output variable names", other, hook.Name)
+			}
+			names[name] = hook.Name
+		}
+	}
+	return nil
+}
+
+// ToVariableName upper-cases the given name and replaces each run of the characters that are not
+// allowed in a variable name with a single "_". The leading and trailing "_" are removed.
+func ToVariableName(name string) string {
+	var sb strings.Builder
+	pending := false
+	for _, r := range strings.ToUpper(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			if pending && sb.Len() > 0 {
+				sb.WriteByte('_')
+			}
+			pending = false
+			sb.WriteRune(r)
+			continue
+		}
+		pending = true
+	}
+	return sb.String()
+}
+
+// SetDuration sets both the human-readable and the numeric duration of the hook execution.
+func (s *HookExecutionStatus) SetDuration(d time.Duration) {
+	s.Duration = d.String()
+	s.DurationSeconds = int64(d.Seconds())
+}
+
+// SetStdout records the tail of the standard output of the hook
+func (s *HookExecutionStatus) SetStdout(stdout string) {
+	s.Stdout = apis.TruncateTail(stdout, apis.MaxHookStdoutSize)
+}
+
+// SetPodStatus records the execution result of the hook in a pod
+func (s *HookExecutionStatus) SetPodStatus(status PodHookExecutionStatus) {
+	for i := range s.Pods {
+		if s.Pods[i].Name == status.Name {
+			s.Pods[i] = status
+			return
+		}
+	}
+	s.Pods = append(s.Pods, status)
+}
+
+// GetPodsPhase calculates the hook execution phase from the execution results in the pods.
+// The hook is failed if it has failed in any of the pods, and succeeded if it has succeeded in all of them.
+func (s HookExecutionStatus) GetPodsPhase() HookExecutionPhase {
+	if len(s.Pods) == 0 {
+		return HookExecutionPending
+	}
+	phase := HookExecutionSucceeded
+	for _, pod := range s.Pods {
+		if pod.Phase == HookExecutionFailed {
+			return HookExecutionFailed
+		}
+		if pod.Phase != HookExecutionSucceeded {
+			phase = HookExecutionPending
+		}
+	}
+	return phase
+}
+
+// ArmUndoHook records the undo hook of a pre hook as pending. It must be called before the pre hook
+// is executed, so that the undo hook is delivered even if the executor is terminated afterward.
+func (s *HookStatus) ArmUndoHook(preHook, undoHook string) {
+	for _, undo := range s.UndoHooks {
+		if undo.PreHook == preHook && undo.Name == undoHook {
+			return
+		}
+	}
+	s.UndoHooks = append(s.UndoHooks, UndoHookStatus{
+		Name:    undoHook,
+		PreHook: preHook,
+		Phase:   HookExecutionPending,
+	})
+}
+
+// RecordUndoHookAttempt records the result of an execution of the given undo hook.
+// A failed undo hook is kept pending, so that it is retried, until it has been attempted
+// MaxUndoHookAttempts times. Only then is it marked as failed.
+func (s *HookStatus) RecordUndoHookAttempt(undoHook string, execErr error, now time.Time) {
+	for i := range s.UndoHooks {
+		undo := &s.UndoHooks[i]
+		if undo.Name != undoHook || undo.Phase != HookExecutionPending {
+			continue
+		}
+		undo.Attempts++
+		undo.LastAttemptTime = &metav1.Time{Time: now}
+		undo.Phase = HookExecutionSucceeded
+		undo.Error = ""
+		if execErr != nil {
+			undo.Phase = HookExecutionPending
+			undo.Error = execErr.Error()
+			if undo.Attempts >= apis.MaxUndoHookAttempts {
+				undo.Phase = HookExecutionFailed
+			}
+		}
+	}
+}
+
+// GetUndeliveredUndoHooks returns the names of the undo hooks that are still pending delivery.
+// An undo hook that is shared by multiple pre hooks is returned once.
+func (s HookStatus) GetUndeliveredUndoHooks() []string {
+	var names []string
+	for _, undo := range s.UndoHooks {
+		if undo.Phase == HookExecutionPending && !slices.Contains(names, undo.Name) {
+			names = append(names, undo.Name)
+		}
+	}
+	return names
+}
+
+// HasUndoHookInPhase returns whether any of the undo hooks is in the given phase
+func (s HookStatus) HasUndoHookInPhase(phase HookExecutionPhase) bool {
+	return slices.ContainsFunc(s.UndoHooks, func(undo UndoHookStatus) bool {
+		return undo.Phase == phase
+	})
+}
+
+// IsUndoHook returns whether the given post hook is the undo hook of any of the pre hooks
+func IsUndoHook(preHooks []HookInfo, postHook string) bool {
+	return slices.ContainsFunc(preHooks, func(h HookInfo) bool {
+		return h.UndoHook == postHook
+	})
+}
+
+// GetUndoHook returns the post hook that undoes the given pre hook
+func GetUndoHook(preHooks, postHooks []HookInfo, preHook string) *HookInfo {
+	for _, pre := range preHooks {
+		if pre.Name != preHook || pre.UndoHook == "" {
+			continue
+		}
+		for i := range postHooks {
+			if postHooks[i].Name == pre.UndoHook {
+				return &postHooks[i]
+			}
+		}
+	}
+	return nil
+}
@@ -221,6 +221,11 @@ func (in *BackupBatchSpec) DeepCopyInto(out *BackupBatchSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PauseInfo != nil {
+		in, out := &in.PauseInfo, &out.PauseInfo
+		*out = new(apis.PauseInfo)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupBatchSpec.
@@ -244,6 +249,13 @@ func (in *BackupBatchStatus) DeepCopyInto(out *BackupBatchStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]BatchStageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -432,6 +444,11 @@ func (in *BackupConfigurationSpec) DeepCopyInto(out *BackupConfigurationSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PauseInfo != nil {
+		in, out := &in.PauseInfo, &out.PauseInfo
+		*out = new(apis.PauseInfo)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfigurationSpec.
@@ -602,6 +619,16 @@ func (in *BackupSessionSpec) DeepCopyInto(out *BackupSessionSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.SuccessPolicy != nil {
+		in, out := &in.SuccessPolicy, &out.SuccessPolicy
+		*out = new(BackupSuccessPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TriggerSource != nil {
+		in, out := &in.TriggerSource, &out.TriggerSource
+		*out = new(TriggerSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSessionSpec.
@@ -648,6 +675,11 @@ func (in *BackupSessionStatus) DeepCopyInto(out *BackupSessionStatus) {
 		in, out := &in.NextRetry, &out.NextRetry
 		*out = (*in).DeepCopy()
 	}
+	if in.Queue != nil {
+		in, out := &in.Queue, &out.Queue
+		*out = new(QueueStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -667,6 +699,91 @@ func (in *BackupSessionStatus) DeepCopy() *BackupSessionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSuccessPolicy) DeepCopyInto(out *BackupSuccessPolicy) {
+	*out = *in
+	if in.Quorum != nil {
+		in, out := &in.Quorum, &out.Quorum
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequiredRepositories != nil {
+		in, out := &in.RequiredRepositories, &out.RequiredRepositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSuccessPolicy.
+func (in *BackupSuccessPolicy) DeepCopy() *BackupSuccessPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSuccessPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTrigger) DeepCopyInto(out *BackupTrigger) {
+	*out = *in
+	if in.ObjectChange != nil {
+		in, out := &in.ObjectChange, &out.ObjectChange
+		*out = new(ObjectChangeTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupSessionSucceeded != nil {
+		in, out := &in.BackupSessionSucceeded, &out.BackupSessionSucceeded
+		*out = new(SessionChainTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Debounce != nil {
+		in, out := &in.Debounce, &out.Debounce
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(TriggerRateLimit)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTrigger.
+func (in *BackupTrigger) DeepCopy() *BackupTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTypePolicy) DeepCopyInto(out *BackupTypePolicy) {
+	*out = *in
+	if in.MaxIncrementals != nil {
+		in, out := &in.MaxIncrementals, &out.MaxIncrementals
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTypePolicy.
+func (in *BackupTypePolicy) DeepCopy() *BackupTypePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTypePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupVerificationSession) DeepCopyInto(out *BackupVerificationSession) {
 	*out = *in
@@ -754,6 +871,21 @@ func (in *BackupVerificationSessionStatus) DeepCopyInto(out *BackupVerificationS
 		*out = new(bool)
 		**out = **in
 	}
+	if in.QueryResults != nil {
+		in, out := &in.QueryResults, &out.QueryResults
+		*out = make([]QueryResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = new(VerificationReport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sandbox != nil {
+		in, out := &in.Sandbox, &out.Sandbox
+		*out = new(SandboxStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -844,6 +976,11 @@ func (in *BackupVerifierSpec) DeepCopyInto(out *BackupVerifierSpec) {
 		*out = new(SchedulerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(VerificationStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]apiv1.Volume, len(*in))
@@ -873,6 +1010,11 @@ func (in *BackupVerifierSpec) DeepCopyInto(out *BackupVerifierSpec) {
 		*out = new(RetryConfig)
 		**out = **in
 	}
+	if in.ReportSigningKey != nil {
+		in, out := &in.ReportSigningKey, &out.ReportSigningKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	in.RuntimeSettings.DeepCopyInto(&out.RuntimeSettings)
 }
 
@@ -901,6 +1043,18 @@ func (in *BatchSession) DeepCopyInto(out *BatchSession) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]BatchStage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BatchHooks != nil {
+		in, out := &in.BatchHooks, &out.BatchHooks
+		*out = new(BackupHooks)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchSession.
@@ -913,6 +1067,119 @@ func (in *BatchSession) DeepCopy() *BatchSession {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BatchStage) DeepCopyInto(out *BatchStage) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchStage.
+func (in *BatchStage) DeepCopy() *BatchStage {
+	if in == nil {
+		return nil
+	}
+	out := new(BatchStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BatchStageStatus) DeepCopyInto(out *BatchStageStatus) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]BatchTargetStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchStageStatus.
+func (in *BatchStageStatus) DeepCopy() *BatchStageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BatchStageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BatchTargetStatus) DeepCopyInto(out *BatchTargetStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchTargetStatus.
+func (in *BatchTargetStatus) DeepCopy() *BatchTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BatchTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackoutPeriod) DeepCopyInto(out *BlackoutPeriod) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+	if in.Recurring != nil {
+		in, out := &in.Recurring, &out.Recurring
+		*out = new(TimeWindow)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackoutPeriod.
+func (in *BlackoutPeriod) DeepCopy() *BlackoutPeriod {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackoutPeriod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentPathFilter) DeepCopyInto(out *ComponentPathFilter) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentPathFilter.
+func (in *ComponentPathFilter) DeepCopy() *ComponentPathFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentPathFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentRestoreStatus) DeepCopyInto(out *ComponentRestoreStatus) {
 	*out = *in
@@ -921,6 +1188,11 @@ func (in *ComponentRestoreStatus) DeepCopyInto(out *ComponentRestoreStatus) {
 		*out = new(RestoreProgress)
 		**out = **in
 	}
+	if in.RestoredFiles != nil {
+		in, out := &in.RestoredFiles, &out.RestoredFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentRestoreStatus.
@@ -1019,9 +1291,76 @@ func (in *FunctionHookExecutorSpec) DeepCopy() *FunctionHookExecutorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeader) DeepCopyInto(out *HTTPHeader) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeader.
+func (in *HTTPHeader) DeepCopy() *HTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHookExecutorSpec) DeepCopyInto(out *HTTPHookExecutorSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryInterval != nil {
+		in, out := &in.RetryInterval, &out.RetryInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHookExecutorSpec.
+func (in *HTTPHookExecutorSpec) DeepCopy() *HTTPHookExecutorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHookExecutorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HookExecutionStatus) DeepCopyInto(out *HookExecutionStatus) {
 	*out = *in
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]HookOutput, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]PodHookExecutionStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookExecutionStatus.
@@ -1047,6 +1386,11 @@ func (in *HookExecutor) DeepCopyInto(out *HookExecutor) {
 		*out = new(PodHookExecutorSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPHookExecutorSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookExecutor.
@@ -1115,18 +1459,59 @@ func (in *HookInfo) DeepCopy() *HookInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookOutput) DeepCopyInto(out *HookOutput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookOutput.
+func (in *HookOutput) DeepCopy() *HookOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(HookOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookOutputDefinition) DeepCopyInto(out *HookOutputDefinition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookOutputDefinition.
+func (in *HookOutputDefinition) DeepCopy() *HookOutputDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(HookOutputDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HookStatus) DeepCopyInto(out *HookStatus) {
 	*out = *in
 	if in.PreHooks != nil {
 		in, out := &in.PreHooks, &out.PreHooks
 		*out = make([]HookExecutionStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.PostHooks != nil {
 		in, out := &in.PostHooks, &out.PostHooks
 		*out = make([]HookExecutionStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UndoHooks != nil {
+		in, out := &in.UndoHooks, &out.UndoHooks
+		*out = make([]UndoHookStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1209,7 +1594,9 @@ func (in *HookTemplateSpec) DeepCopyInto(out *HookTemplateSpec) {
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]apis.ParameterDefinition, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Action != nil {
 		in, out := &in.Action, &out.Action
@@ -1221,6 +1608,11 @@ func (in *HookTemplateSpec) DeepCopyInto(out *HookTemplateSpec) {
 		*out = new(HookExecutor)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]HookOutputDefinition, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookTemplateSpec.
@@ -1387,6 +1779,11 @@ func (in *MSSQLServerQuery) DeepCopyInto(out *MSSQLServerQuery) {
 		*out = new(MatchExpression)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(ScalarQuery)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MSSQLServerQuery.
@@ -1482,6 +1879,11 @@ func (in *MariaDBQuery) DeepCopyInto(out *MariaDBQuery) {
 		*out = new(MatchExpression)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(ScalarQuery)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBQuery.
@@ -1542,6 +1944,11 @@ func (in *MySQLQuery) DeepCopyInto(out *MySQLQuery) {
 		*out = new(MatchExpression)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(ScalarQuery)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLQuery.
@@ -1554,6 +1961,31 @@ func (in *MySQLQuery) DeepCopy() *MySQLQuery {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectChangeTrigger) DeepCopyInto(out *ObjectChangeTrigger) {
+	*out = *in
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectChangeTrigger.
+func (in *ObjectChangeTrigger) DeepCopy() *ObjectChangeTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectChangeTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OffshootStatus) DeepCopyInto(out *OffshootStatus) {
 	*out = *in
@@ -1614,14 +2046,70 @@ func (in *PITR) DeepCopy() *PITR {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PausedBackup) DeepCopyInto(out *PausedBackup) {
+	*out = *in
+	out.TypedObjectReference = in.TypedObjectReference
+	if in.OriginalPauseInfo != nil {
+		in, out := &in.OriginalPauseInfo, &out.OriginalPauseInfo
+		*out = new(apis.PauseInfo)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PausedBackup.
+func (in *PausedBackup) DeepCopy() *PausedBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(PausedBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodHookExecutionStatus) DeepCopyInto(out *PodHookExecutionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodHookExecutionStatus.
+func (in *PodHookExecutionStatus) DeepCopy() *PodHookExecutionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodHookExecutionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodHookExecutorSpec) DeepCopyInto(out *PodHookExecutorSpec) {
 	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Owner != nil {
 		in, out := &in.Owner, &out.Owner
 		*out = new(metav1.OwnerReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(PodRoleSpec)
+		**out = **in
+	}
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodHookExecutorSpec.
@@ -1634,6 +2122,21 @@ func (in *PodHookExecutorSpec) DeepCopy() *PodHookExecutorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodRoleSpec) DeepCopyInto(out *PodRoleSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRoleSpec.
+func (in *PodRoleSpec) DeepCopy() *PodRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresQuery) DeepCopyInto(out *PostgresQuery) {
 	*out = *in
@@ -1642,6 +2145,11 @@ func (in *PostgresQuery) DeepCopyInto(out *PostgresQuery) {
 		*out = new(MatchExpression)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(ScalarQuery)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresQuery.
@@ -1654,6 +2162,40 @@ func (in *PostgresQuery) DeepCopy() *PostgresQuery {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryResult) DeepCopyInto(out *QueryResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryResult.
+func (in *QueryResult) DeepCopy() *QueryResult {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueueStatus) DeepCopyInto(out *QueueStatus) {
+	*out = *in
+	if in.EnqueueTime != nil {
+		in, out := &in.EnqueueTime, &out.EnqueueTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueStatus.
+func (in *QueueStatus) DeepCopy() *QueueStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QueueStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisQuery) DeepCopyInto(out *RedisQuery) {
 	*out = *in
@@ -1768,6 +2310,11 @@ func (in *ResourceFoundStatus) DeepCopy() *ResourceFoundStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreDataSource) DeepCopyInto(out *RestoreDataSource) {
 	*out = *in
+	if in.SnapshotSelector != nil {
+		in, out := &in.SnapshotSelector, &out.SnapshotSelector
+		*out = new(SnapshotSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PITR != nil {
 		in, out := &in.PITR, &out.PITR
 		*out = new(PITR)
@@ -1832,6 +2379,11 @@ func (in *RestoreOption) DeepCopyInto(out *RestoreOption) {
 		*out = new(v1.TypedObjectReference)
 		**out = **in
 	}
+	if in.Sandbox != nil {
+		in, out := &in.Sandbox, &out.Sandbox
+		*out = new(SandboxSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AddonInfo != nil {
 		in, out := &in.AddonInfo, &out.AddonInfo
 		*out = new(AddonInfo)
@@ -1956,6 +2508,13 @@ func (in *RestoreSessionSpec) DeepCopyInto(out *RestoreSessionSpec) {
 		*out = new(ManifestRestoreOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PathFilters != nil {
+		in, out := &in.PathFilters, &out.PathFilters
+		*out = make([]ComponentPathFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSessionSpec.
@@ -1997,8 +2556,10 @@ func (in *RestoreSessionStatus) DeepCopyInto(out *RestoreSessionStatus) {
 	}
 	if in.PausedBackups != nil {
 		in, out := &in.PausedBackups, &out.PausedBackups
-		*out = make([]v1.TypedObjectReference, len(*in))
-		copy(*out, *in)
+		*out = make([]PausedBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -2072,9 +2633,118 @@ func (in *RetryConfig) DeepCopy() *RetryConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxSpec) DeepCopyInto(out *SandboxSpec) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.OperatorNamespaces != nil {
+		in, out := &in.OperatorNamespaces, &out.OperatorNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxSpec.
+func (in *SandboxSpec) DeepCopy() *SandboxSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxStatus) DeepCopyInto(out *SandboxStatus) {
+	*out = *in
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiryTime != nil {
+		in, out := &in.ExpiryTime, &out.ExpiryTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxStatus.
+func (in *SandboxStatus) DeepCopy() *SandboxStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalarQuery) DeepCopyInto(out *ScalarQuery) {
+	*out = *in
+	if in.Result != nil {
+		in, out := &in.Result, &out.Result
+		*out = new(MatchExpression)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalarQuery.
+func (in *ScalarQuery) DeepCopy() *ScalarQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalarQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulerSpec) DeepCopyInto(out *SchedulerSpec) {
 	*out = *in
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]TimeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Blackouts != nil {
+		in, out := &in.Blackouts, &out.Blackouts
+		*out = make([]BlackoutPeriod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.StartingDeadlineSeconds != nil {
 		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
 		*out = new(int64)
@@ -2108,6 +2778,21 @@ func (in *SchedulerSpec) DeepCopy() *SchedulerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScriptVerificationResult) DeepCopyInto(out *ScriptVerificationResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptVerificationResult.
+func (in *ScriptVerificationResult) DeepCopy() *ScriptVerificationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ScriptVerificationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScriptVerifierSpec) DeepCopyInto(out *ScriptVerifierSpec) {
 	*out = *in
@@ -2160,6 +2845,26 @@ func (in *Session) DeepCopy() *Session {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionChainTrigger) DeepCopyInto(out *SessionChainTrigger) {
+	*out = *in
+	if in.Invoker != nil {
+		in, out := &in.Invoker, &out.Invoker
+		*out = new(corev1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionChainTrigger.
+func (in *SessionChainTrigger) DeepCopy() *SessionChainTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionChainTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SessionConfig) DeepCopyInto(out *SessionConfig) {
 	*out = *in
@@ -2168,6 +2873,28 @@ func (in *SessionConfig) DeepCopyInto(out *SessionConfig) {
 		*out = new(SchedulerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]BackupTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RPO != nil {
+		in, out := &in.RPO, &out.RPO
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SuccessPolicy != nil {
+		in, out := &in.SuccessPolicy, &out.SuccessPolicy
+		*out = new(BackupSuccessPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupTypePolicy != nil {
+		in, out := &in.BackupTypePolicy, &out.BackupTypePolicy
+		*out = new(BackupTypePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Hooks != nil {
 		in, out := &in.Hooks, &out.Hooks
 		*out = new(BackupHooks)
@@ -2198,6 +2925,21 @@ func (in *SessionConfig) DeepCopy() *SessionConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SessionStatus) DeepCopyInto(out *SessionStatus) {
 	*out = *in
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]TriggerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -2225,6 +2967,11 @@ func (in *SinglestoreQuery) DeepCopyInto(out *SinglestoreQuery) {
 		*out = new(MatchExpression)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(ScalarQuery)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinglestoreQuery.
@@ -2237,6 +2984,30 @@ func (in *SinglestoreQuery) DeepCopy() *SinglestoreQuery {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotSelector) DeepCopyInto(out *SnapshotSelector) {
+	*out = *in
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = (*in).DeepCopy()
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotSelector.
+func (in *SnapshotSelector) DeepCopy() *SnapshotSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotStatus) DeepCopyInto(out *SnapshotStatus) {
 	*out = *in
@@ -2286,6 +3057,11 @@ func (in *Summary) DeepCopyInto(out *Summary) {
 		*out = new(v1.TypedObjectReference)
 		**out = **in
 	}
+	if in.TriggerSource != nil {
+		in, out := &in.TriggerSource, &out.TriggerSource
+		*out = new(TriggerSource)
+		(*in).DeepCopyInto(*out)
+	}
 	out.Status = in.Status
 }
 
@@ -2436,6 +3212,238 @@ func (in *TaskReference) DeepCopy() *TaskReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeWindow) DeepCopyInto(out *TimeWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+	if in.DaysOfMonth != nil {
+		in, out := &in.DaysOfMonth, &out.DaysOfMonth
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeWindow.
+func (in *TimeWindow) DeepCopy() *TimeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerRateLimit) DeepCopyInto(out *TriggerRateLimit) {
+	*out = *in
+	out.Period = in.Period
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerRateLimit.
+func (in *TriggerRateLimit) DeepCopy() *TriggerRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerSource) DeepCopyInto(out *TriggerSource) {
+	*out = *in
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = new(v1.TypedObjectReference)
+		**out = **in
+	}
+	if in.EventTime != nil {
+		in, out := &in.EventTime, &out.EventTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerSource.
+func (in *TriggerSource) DeepCopy() *TriggerSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerStatus) DeepCopyInto(out *TriggerStatus) {
+	*out = *in
+	if in.LastEventTime != nil {
+		in, out := &in.LastEventTime, &out.LastEventTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TriggeredTimes != nil {
+		in, out := &in.TriggeredTimes, &out.TriggeredTimes
+		*out = make([]metav1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerStatus.
+func (in *TriggerStatus) DeepCopy() *TriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UndoHookStatus) DeepCopyInto(out *UndoHookStatus) {
+	*out = *in
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UndoHookStatus.
+func (in *UndoHookStatus) DeepCopy() *UndoHookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UndoHookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationReport) DeepCopyInto(out *VerificationReport) {
+	*out = *in
+	if in.RestoreTarget != nil {
+		in, out := &in.RestoreTarget, &out.RestoreTarget
+		*out = new(v1.TypedObjectReference)
+		**out = **in
+	}
+	if in.RestoreStartTime != nil {
+		in, out := &in.RestoreStartTime, &out.RestoreStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestoreCompletionTime != nil {
+		in, out := &in.RestoreCompletionTime, &out.RestoreCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Script != nil {
+		in, out := &in.Script, &out.Script
+		*out = new(ScriptVerificationResult)
+		**out = **in
+	}
+	if in.Artifact != nil {
+		in, out := &in.Artifact, &out.Artifact
+		*out = new(VerificationReportArtifact)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationReport.
+func (in *VerificationReport) DeepCopy() *VerificationReport {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationReportArtifact) DeepCopyInto(out *VerificationReportArtifact) {
+	*out = *in
+	if in.UploadTime != nil {
+		in, out := &in.UploadTime, &out.UploadTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationReportArtifact.
+func (in *VerificationReportArtifact) DeepCopy() *VerificationReportArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationReportArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationSampling) DeepCopyInto(out *VerificationSampling) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationSampling.
+func (in *VerificationSampling) DeepCopy() *VerificationSampling {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationSampling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationStrategy) DeepCopyInto(out *VerificationStrategy) {
+	*out = *in
+	if in.SnapshotInterval != nil {
+		in, out := &in.SnapshotInterval, &out.SnapshotInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(VerificationSampling)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationStrategy.
+func (in *VerificationStrategy) DeepCopy() *VerificationStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTrigger) DeepCopyInto(out *WebhookTrigger) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTrigger.
+func (in *WebhookTrigger) DeepCopy() *WebhookTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadManifestOptions) DeepCopyInto(out *WorkloadManifestOptions) {
 	*out = *in
@@ -17,10 +17,24 @@ limitations under the License.
 package apis
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+	"unicode/utf8"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	cutil "kmodules.xyz/client-go/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var (
@@ -48,3 +62,209 @@ func UpsertLabels(oldLabels, newLabels map[string]string) map[string]string {
 	maps.Copy(oldLabels, newLabels)
 	return oldLabels
 }
+
+// IsPaused returns whether a resource is paused at the given time. A pause expires at its resume time.
+// If a session name is given, it also considers the pause that applies to the specific sessions only.
+func IsPaused(paused bool, info *PauseInfo, now time.Time, session ...string) bool {
+	if !paused || info.IsExpired(now) {
+		return false
+	}
+	if info == nil || len(info.Sessions) == 0 {
+		return true
+	}
+	return len(session) > 0 && slices.Contains(info.Sessions, session[0])
+}
+
+// IsExpired returns whether the pause should be resumed automatically at the given time
+func (p *PauseInfo) IsExpired(now time.Time) bool {
+	return p != nil && p.ResumeAt != nil && !now.Before(p.ResumeAt.Time)
+}
+
+// SetPauseInfo returns the pause details of a paused resource. When the resource is being paused, i.e. it was not
+// paused before, the given user and time are always recorded. Otherwise, they are kept from the previous pause
+// details, so that the clients can not forge who paused the resource and when.
+func SetPauseInfo(info, old *PauseInfo, wasPaused bool, user string, now time.Time) *PauseInfo {
+	if info == nil {
+		info = &PauseInfo{}
+	}
+	if !wasPaused {
+		info.PausedBy = user
+		info.PausedAt = &metav1.Time{Time: now}
+		return info
+	}
+
+	info.PausedBy, info.PausedAt = "", nil
+	if old != nil {
+		info.PausedBy, info.PausedAt = old.PausedBy, old.PausedAt
+	}
+	return info
+}
+
+// DefaultPauseInfo returns the pause details of a resource being admitted by the webhook.
+// The pause details are dropped when the resource is not paused. Otherwise, the user and the time of the pause
+// are set from the admission request according to SetPauseInfo.
+func DefaultPauseInfo(ctx context.Context, paused bool, info *PauseInfo) *PauseInfo {
+	if !paused {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return SetPauseInfo(info, nil, false, "", time.Now())
+	}
+
+	var old struct {
+		Spec struct {
+			Paused    bool       `json:"paused,omitempty"`
+			PauseInfo *PauseInfo `json:"pauseInfo,omitempty"`
+		} `json:"spec,omitempty"`
+	}
+	if len(req.OldObject.Raw) > 0 {
+		if err := json.Unmarshal(req.OldObject.Raw, &old); err != nil {
+			old.Spec.Paused, old.Spec.PauseInfo = false, nil
+		}
+	}
+	return SetPauseInfo(info, old.Spec.PauseInfo, old.Spec.Paused, req.UserInfo.Username, time.Now())
+}
+
+// SetPausedCondition sets the Paused condition according to the pause state of the resource at the given time.
+// The condition is removed when the resource is not paused.
+func SetPausedCondition(conditions []kmapi.Condition, paused bool, info *PauseInfo, now time.Time) []kmapi.Condition {
+	if !paused || info.IsExpired(now) {
+		return cutil.RemoveCondition(conditions, TypePaused)
+	}
+
+	newCond := kmapi.Condition{
+		Type:    TypePaused,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonPaused,
+		Message: "Paused",
+	}
+	if info != nil {
+		if len(info.Sessions) > 0 {
+			newCond.Reason = ReasonSessionsPaused
+			newCond.Message = fmt.Sprintf("Sessions %s are paused", strings.Join(info.Sessions, ", "))
+		}
+		if info.PausedBy != "" {
+			newCond.Message += fmt.Sprintf(" by %s", info.PausedBy)
+		}
+		if info.Reason != "" {
+			newCond.Message += fmt.Sprintf(". Reason: %s", info.Reason)
+		}
+		if info.ResumeAt != nil {
+			newCond.Message += fmt.Sprintf(". It will be resumed at %s", info.ResumeAt.Format(time.RFC3339))
+		}
+	}
+	newCond.Message += "."
+	return cutil.SetCondition(conditions, newCond)
+}
+
+// ValidateParameters validates the provided parameters against their definitions. The parameters that are
+// not defined are rejected only if any definition specifies a type, so that the existing users that pass
+// extra parameters to untyped definitions keep working.
+func ValidateParameters(definitions []ParameterDefinition, params map[string]any) error {
+	defined := make(map[string]bool, len(definitions))
+	for _, def := range definitions {
+		defined[def.Name] = true
+		value, ok := params[def.Name]
+		if !ok {
+			if def.Required && def.Default == "" {
+				return fmt.Errorf("required parameter %q is missing", def.Name)
+			}
+			continue
+		}
+		if err := def.ValidateValue(value); err != nil {
+			return err
+		}
+	}
+
+	if !slices.ContainsFunc(definitions, func(def ParameterDefinition) bool { return def.Type != "" }) {
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		if !defined[name] {
+			return fmt.Errorf("unknown parameter %q", name)
+		}
+	}
+	return nil
+}
+
+// Validate checks whether the parameter definition itself is valid
+func (p ParameterDefinition) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("parameter name can not be empty")
+	}
+	if p.Type == ParameterTypeEnum && len(p.Enum) == 0 {
+		return fmt.Errorf("enum can not be empty for enum type parameter %q", p.Name)
+	}
+	if p.Type != ParameterTypeEnum && len(p.Enum) > 0 {
+		return fmt.Errorf("enum can be specified only for enum type parameter %q", p.Name)
+	}
+	if p.Pattern != "" {
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern for parameter %q. Reason: %w", p.Name, err)
+		}
+	}
+	if p.Default != "" {
+		return p.ValidateValue(p.Default)
+	}
+	return nil
+}
+
+// ValidateValue checks whether the given value satisfies the type and the pattern of the parameter
+func (p ParameterDefinition) ValidateValue(value any) error {
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case bool:
+		str = strconv.FormatBool(v)
+	case float64:
+		str = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("invalid value for parameter %q. Value must be a string, number or boolean", p.Name)
+	}
+
+	switch p.Type {
+	case ParameterTypeInteger:
+		if _, err := strconv.ParseInt(str, 10, 64); err != nil {
+			return fmt.Errorf("invalid value %q for parameter %q. Value must be an integer", str, p.Name)
+		}
+	case ParameterTypeBoolean:
+		if str != "true" && str != "false" {
+			return fmt.Errorf("invalid value %q for parameter %q. Value must be either true or false", str, p.Name)
+		}
+	case ParameterTypeEnum:
+		if !slices.Contains(p.Enum, str) {
+			return fmt.Errorf("invalid value %q for parameter %q. Value must be one of %v", str, p.Name, p.Enum)
+		}
+	case ParameterTypeSecretRef:
+		if errs := validation.IsDNS1123Subdomain(str); len(errs) > 0 {
+			return fmt.Errorf("invalid Secret name %q for parameter %q. Reason: %s", str, p.Name, strings.Join(errs, ", "))
+		}
+	}
+
+	if p.Pattern != "" {
+		matched, err := regexp.MatchString(p.Pattern, str)
+		if err != nil {
+			return fmt.Errorf("invalid pattern for parameter %q. Reason: %w", p.Name, err)
+		}
+		if !matched {
+			return fmt.Errorf("invalid value %q for parameter %q. Value must match the pattern %q", str, p.Name, p.Pattern)
+		}
+	}
+	return nil
+}
+
+// TruncateTail returns the last maxSize bytes of s at most. The cut is moved forward to the
+// start of a rune, so that a multibyte character is never split.
+func TruncateTail(s string, maxSize int) string {
+	if len(s) <= maxSize {
+		return s
+	}
+	start := len(s) - maxSize
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	return s[start:]
+}
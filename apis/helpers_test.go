@@ -0,0 +1,143 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestValidateParameters(t *testing.T) {
+	definitions := []ParameterDefinition{
+		{Name: "database", Required: true},
+		{Name: "timeout", Type: ParameterTypeInteger, Default: "30"},
+		{Name: "force", Type: ParameterTypeBoolean},
+		{Name: "mode", Type: ParameterTypeEnum, Enum: []string{"read-only", "flush"}},
+		{Name: "credentials", Type: ParameterTypeSecretRef},
+		{Name: "table", Pattern: "^[a-z_]+$"},
+	}
+
+	tests := []struct {
+		name    string
+		params  map[string]any
+		wantErr bool
+	}{
+		{
+			name: "Parameters should be valid if all values match their definitions",
+			params: map[string]any{
+				"database":    "mysql",
+				"timeout":     float64(60),
+				"force":       true,
+				"mode":        "flush",
+				"credentials": "mysql-auth",
+				"table":       "users",
+			},
+		},
+		{
+			name:    "Parameters should be invalid if a required parameter is missing",
+			params:  map[string]any{"timeout": "60"},
+			wantErr: true,
+		},
+		{
+			name:    "Parameters should be invalid if an unknown parameter is provided",
+			params:  map[string]any{"database": "mysql", "retries": "3"},
+			wantErr: true,
+		},
+		{
+			name:    "Parameters should be invalid if an integer parameter is not an integer",
+			params:  map[string]any{"database": "mysql", "timeout": "1m"},
+			wantErr: true,
+		},
+		{
+			name:    "Parameters should be invalid if a boolean parameter is not a boolean",
+			params:  map[string]any{"database": "mysql", "force": "yes"},
+			wantErr: true,
+		},
+		{
+			name:    "Parameters should be invalid if an enum parameter is not an allowed value",
+			params:  map[string]any{"database": "mysql", "mode": "lock"},
+			wantErr: true,
+		},
+		{
+			name:    "Parameters should be invalid if a secret reference is not a valid name",
+			params:  map[string]any{"database": "mysql", "credentials": "Mysql_Auth"},
+			wantErr: true,
+		},
+		{
+			name:    "Parameters should be invalid if a value does not match the pattern",
+			params:  map[string]any{"database": "mysql", "table": "users; DROP"},
+			wantErr: true,
+		},
+		{
+			name:    "Parameters should be invalid if a value is not a scalar",
+			params:  map[string]any{"database": map[string]any{"name": "mysql"}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateParameters(definitions, test.params)
+			assert.Equal(t, test.wantErr, err != nil, err)
+		})
+	}
+
+	untyped := []ParameterDefinition{{Name: "database", Required: true}}
+	assert.NoError(t, ValidateParameters(untyped, map[string]any{"database": "mysql", "retries": "3"}),
+		"unknown parameters should be allowed if no definition specifies a type")
+}
+
+func TestParameterDefinitionValidate(t *testing.T) {
+	assert.Nil(t, ParameterDefinition{Name: "mode", Type: ParameterTypeEnum, Enum: []string{"a"}, Default: "a"}.Validate())
+	assert.NotNil(t, ParameterDefinition{Name: "mode", Type: ParameterTypeEnum}.Validate())
+	assert.NotNil(t, ParameterDefinition{Name: "mode", Enum: []string{"a"}}.Validate())
+	assert.NotNil(t, ParameterDefinition{Name: "table", Pattern: "[a-"}.Validate())
+	assert.NotNil(t, ParameterDefinition{Name: "timeout", Type: ParameterTypeInteger, Default: "forever"}.Validate())
+}
+
+func TestDefaultPauseInfo(t *testing.T) {
+	pausedAt := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	forged := &PauseInfo{Reason: "maintenance", PausedBy: "someone-else", PausedAt: &metav1.Time{}}
+
+	newContext := func(oldObject string) context.Context {
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo:  authenticationv1.UserInfo{Username: "admin"},
+				OldObject: runtime.RawExtension{Raw: []byte(oldObject)},
+			},
+		})
+	}
+
+	assert.Nil(t, DefaultPauseInfo(newContext(""), false, forged.DeepCopy()))
+
+	info := DefaultPauseInfo(newContext(`{"spec":{"paused":false}}`), true, forged.DeepCopy())
+	assert.Equal(t, "admin", info.PausedBy)
+	assert.Equal(t, "maintenance", info.Reason)
+	assert.False(t, info.PausedAt.IsZero())
+
+	info = DefaultPauseInfo(newContext(`{"spec":{"paused":true,"pauseInfo":{"pausedBy":"operator","pausedAt":"2024-01-01T00:00:00Z"}}}`), true, forged.DeepCopy())
+	assert.Equal(t, "operator", info.PausedBy)
+	assert.True(t, pausedAt.Equal(info.PausedAt))
+}
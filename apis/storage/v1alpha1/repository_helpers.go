@@ -17,9 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"slices"
+	"time"
+
 	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/crds"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"kmodules.xyz/client-go/apiextensions"
 	cutil "kmodules.xyz/client-go/conditions"
 	"kmodules.xyz/client-go/meta"
@@ -44,3 +49,138 @@ func (r *Repository) OffshootLabels() map[string]string {
 	newLabels[apis.KubeStashInvokerNamespace] = r.Namespace
 	return apis.UpsertLabels(r.Labels, newLabels)
 }
+
+// AddIntegrityCheckResult records the result of an integrity check at the beginning of the check history.
+// The history is trimmed according to the history limit of the integrity check policy and the
+// integrity of the Repository is updated from the result.
+func (r *Repository) AddIntegrityCheckResult(result IntegrityCheckResult) {
+	r.Status.IntegrityChecks = append([]IntegrityCheckResult{result}, r.Status.IntegrityChecks...)
+	if limit := int(r.GetIntegrityCheckHistoryLimit()); len(r.Status.IntegrityChecks) > limit {
+		r.Status.IntegrityChecks = r.Status.IntegrityChecks[:limit]
+	}
+	if result.Integrity != nil {
+		r.Status.Integrity = ptr.To(*result.Integrity)
+	}
+}
+
+// GetLastIntegrityCheck returns the result of the most recent integrity check.
+func (r *Repository) GetLastIntegrityCheck() *IntegrityCheckResult {
+	if len(r.Status.IntegrityChecks) == 0 {
+		return nil
+	}
+	return &r.Status.IntegrityChecks[0]
+}
+
+func (r *Repository) GetIntegrityCheckHistoryLimit() int32 {
+	if r.Spec.IntegrityCheck != nil && r.Spec.IntegrityCheck.HistoryLimit != nil {
+		return *r.Spec.IntegrityCheck.HistoryLimit
+	}
+	return DefaultIntegrityCheckHistoryLimit
+}
+
+func (r *Repository) GetIntegrityCheckResultValidity() *metav1.Duration {
+	if r.Spec.IntegrityCheck == nil {
+		return nil
+	}
+	return r.Spec.IntegrityCheck.ResultValidity
+}
+
+// IsIntegrityCheckDue returns true if the integrity of the Repository has never been checked
+// or the last check is older than the result validity period.
+func (r *Repository) IsIntegrityCheckDue(now time.Time) bool {
+	last := r.GetLastIntegrityCheck()
+	if last == nil || last.CompletionTime == nil {
+		return true
+	}
+	validity := r.GetIntegrityCheckResultValidity()
+	return validity != nil && now.Sub(last.CompletionTime.Time) > validity.Duration
+}
+
+// RefreshIntegrity reports the integrity of the Repository and the given Snapshots of it as unknown
+// once their last integrity check is older than the result validity of the integrity check policy.
+func (r *Repository) RefreshIntegrity(snapshots []Snapshot, now time.Time) {
+	validity := r.GetIntegrityCheckResultValidity()
+	if validity != nil && r.GetLastIntegrityCheck() != nil && r.IsIntegrityCheckDue(now) {
+		r.Status.Integrity = nil
+	}
+	for i := range snapshots {
+		snapshots[i].RefreshIntegrity(validity, now)
+	}
+}
+
+func (p *IntegrityCheckPolicy) GetMode() IntegrityCheckMode {
+	if p.Mode == "" {
+		return IntegrityCheckModeMetadataOnly
+	}
+	return p.Mode
+}
+
+// GetReadDataPercentage returns the percentage of the data to read back during the check.
+// It returns 0 when no data should be read.
+func (p *IntegrityCheckPolicy) GetReadDataPercentage() int32 {
+	if p.GetMode() != IntegrityCheckModeReadData {
+		return 0
+	}
+	if p.ReadDataPercentage == nil {
+		return 100
+	}
+	return *p.ReadDataPercentage
+}
+
+// IsPaused returns whether the Repository is paused at the given time
+func (r *Repository) IsPaused(now time.Time) bool {
+	return apis.IsPaused(r.Spec.Paused, r.Spec.PauseInfo, now)
+}
+
+// SetPausedCondition reflects the pause state of the Repository at the given time in its conditions
+func (r *Repository) SetPausedCondition(now time.Time) {
+	r.Status.Conditions = apis.SetPausedCondition(r.Status.Conditions, r.Spec.Paused, r.Spec.PauseInfo, now)
+}
+
+// SetSize sets both the human-readable and the numeric size of the Repository.
+func (r *Repository) SetSize(sizeInByte uint64) {
+	r.Status.Size = FormatBytes(sizeInByte)
+	r.Status.SizeBytes = int64(sizeInByte)
+}
+
+// SetVerificationSummary summarises the verification results of the given Snapshots of the Repository
+func (r *Repository) SetVerificationSummary(snapshots []Snapshot) {
+	type verification struct {
+		snapshot string
+		result   VerificationResult
+	}
+
+	summary := &VerificationSummary{}
+	var history []verification
+	for _, snap := range snapshots {
+		switch snap.Status.VerificationStatus {
+		case SnapshotVerified:
+			summary.VerifiedSnapshots++
+		case SnapshotVerificationFailed:
+			summary.FailedSnapshots++
+		}
+		for _, result := range snap.Status.VerificationHistory {
+			if result.VerificationTime != nil {
+				history = append(history, verification{snapshot: snap.Name, result: result})
+			}
+		}
+	}
+	if len(history) == 0 {
+		r.Status.Verification = nil
+		return
+	}
+
+	slices.SortFunc(history, func(a, b verification) int {
+		return b.result.VerificationTime.Compare(a.result.VerificationTime.Time)
+	})
+	summary.LastVerificationTime = history[0].result.VerificationTime
+	summary.LastVerifiedSnapshot = history[0].snapshot
+	summary.LastStatus = history[0].result.Status
+	for _, v := range history {
+		if v.result.Status != SnapshotVerificationFailed {
+			break
+		}
+		summary.ConsecutiveFailures++
+	}
+	r.Status.Verification = summary
+}
@@ -0,0 +1,107 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestRepositoryIntegrityCheckHistory(t *testing.T) {
+	repo := &Repository{
+		Spec: RepositorySpec{
+			IntegrityCheck: &IntegrityCheckPolicy{HistoryLimit: ptr.To(int32(2))},
+		},
+	}
+	assert.True(t, repo.IsIntegrityCheckDue(time.Now()))
+
+	for i := 0; i < 3; i++ {
+		repo.AddIntegrityCheckResult(IntegrityCheckResult{
+			CompletionTime: &metav1.Time{Time: time.Now()},
+			Integrity:      ptr.To(i != 2),
+			ErrorsFound:    int64(i),
+		})
+	}
+	assert.Len(t, repo.Status.IntegrityChecks, 2)
+	assert.Equal(t, int64(2), repo.GetLastIntegrityCheck().ErrorsFound)
+	assert.False(t, *repo.Status.Integrity)
+	assert.False(t, repo.IsIntegrityCheckDue(time.Now()))
+}
+
+func TestRepositoryRefreshIntegrity(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	checkTime := metav1.NewTime(now.Add(-time.Hour))
+	repo := &Repository{
+		Spec: RepositorySpec{
+			IntegrityCheck: &IntegrityCheckPolicy{ResultValidity: &metav1.Duration{Duration: 24 * time.Hour}},
+		},
+	}
+	repo.AddIntegrityCheckResult(IntegrityCheckResult{CompletionTime: &checkTime, Integrity: ptr.To(true)})
+
+	snap := sampleSnapshot(1, map[string]Component{
+		"dump": {Driver: apis.DriverRestic, ResticStats: []ResticStats{{}}},
+	})
+	snap.SetIntegrity(true, checkTime, repo.GetIntegrityCheckResultValidity())
+	snapshots := []Snapshot{*snap}
+
+	repo.RefreshIntegrity(snapshots, now)
+	assert.True(t, *repo.Status.Integrity)
+	assert.True(t, *snapshots[0].Status.Integrity)
+
+	repo.RefreshIntegrity(snapshots, now.Add(48*time.Hour))
+	assert.Nil(t, repo.Status.Integrity, "a stale check result should not be reported")
+	assert.Nil(t, snapshots[0].Status.Integrity, "a stale check result should not be reported")
+}
+
+func TestRepositoryVerificationSummary(t *testing.T) {
+	now := time.Now()
+	verify := func(snap *Snapshot, status VerificationStatus, ago time.Duration) {
+		snap.RecordVerification(VerificationResult{
+			Session:          snap.Name + "-verification",
+			Status:           status,
+			VerificationTime: &metav1.Time{Time: now.Add(-ago)},
+		})
+	}
+
+	daily := Snapshot{ObjectMeta: metav1.ObjectMeta{Name: "daily"}}
+	weekly := Snapshot{ObjectMeta: metav1.ObjectMeta{Name: "weekly"}}
+	verify(&weekly, SnapshotVerified, 3*time.Hour)
+	verify(&daily, SnapshotVerified, 2*time.Hour)
+	verify(&daily, SnapshotVerificationFailed, time.Hour)
+	verify(&weekly, SnapshotVerificationFailed, time.Minute)
+	assert.Equal(t, SnapshotVerificationFailed, daily.Status.VerificationStatus)
+	assert.Len(t, daily.Status.VerificationHistory, 2)
+
+	repo := &Repository{}
+	repo.SetVerificationSummary([]Snapshot{daily, weekly, {ObjectMeta: metav1.ObjectMeta{Name: "hourly"}}})
+	assert.Equal(t, &VerificationSummary{
+		LastVerificationTime: &metav1.Time{Time: now.Add(-time.Minute)},
+		LastVerifiedSnapshot: "weekly",
+		LastStatus:           SnapshotVerificationFailed,
+		FailedSnapshots:      2,
+		ConsecutiveFailures:  2,
+	}, repo.Status.Verification)
+
+	repo.SetVerificationSummary(nil)
+	assert.Nil(t, repo.Status.Verification)
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"kubestash.dev/apimachinery/apis"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kmapi "kmodules.xyz/client-go/api/v1"
 )
@@ -82,8 +84,64 @@ type RepositorySpec struct {
 	// KubeStash will not process any further event for the Repository.
 	// +optional
 	Paused bool `json:"paused,omitempty"`
+
+	// PauseInfo specifies the details of the pause, i.e. why and by whom the Repository was paused and when it should be resumed.
+	// It is only effective when `paused` is set to `true`.
+	// +optional
+	PauseInfo *apis.PauseInfo `json:"pauseInfo,omitempty"`
+
+	// IntegrityCheck specifies the policy for periodically checking the integrity of the backed up data of this Repository.
+	// +optional
+	IntegrityCheck *IntegrityCheckPolicy `json:"integrityCheck,omitempty"`
 }
 
+// IntegrityCheckPolicy specifies when and how the integrity of a Repository should be checked
+type IntegrityCheckPolicy struct {
+	// Schedule specifies the schedule of the integrity check in Cron format, see https://en.wikipedia.org/wiki/Cron.
+	Schedule string `json:"schedule,omitempty"`
+
+	// Mode specifies what should be checked.
+	// The valid values are:
+	// - "MetadataOnly": Only the structure and metadata of the repository are checked. This is the default behavior.
+	// - "ReadData": The actual data is read back and verified in addition to the metadata.
+	// +kubebuilder:default=MetadataOnly
+	// +optional
+	Mode IntegrityCheckMode `json:"mode,omitempty"`
+
+	// ReadDataPercentage specifies the percentage of the data to read back in "ReadData" mode.
+	// If not specified, all the data will be read.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ReadDataPercentage *int32 `json:"readDataPercentage,omitempty"`
+
+	// ResultValidity specifies how long the result of an integrity check should be trusted.
+	// The integrity of the Snapshots will be reported as unknown once the last check is older than this.
+	// +optional
+	ResultValidity *metav1.Duration `json:"resultValidity,omitempty"`
+
+	// RepairDamagedPacks specifies whether the damaged packs reported by the check should be repaired.
+	// The data that can not be salvaged from the damaged packs will be removed from the repository.
+	// +optional
+	RepairDamagedPacks bool `json:"repairDamagedPacks,omitempty"`
+
+	// HistoryLimit specifies the number of integrity check results to keep in the status.
+	// +kubebuilder:default=5
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// IntegrityCheckMode specifies what should be checked during the integrity check
+// +kubebuilder:validation:Enum=MetadataOnly;ReadData
+type IntegrityCheckMode string
+
+const (
+	IntegrityCheckModeMetadataOnly IntegrityCheckMode = "MetadataOnly"
+	IntegrityCheckModeReadData     IntegrityCheckMode = "ReadData"
+)
+
+const DefaultIntegrityCheckHistoryLimit int32 = 5
+
 // RepositoryStatus defines the observed state of Repository
 type RepositoryStatus struct {
 	// Phase represents the current state of the Repository.
@@ -98,6 +156,14 @@ type RepositoryStatus struct {
 	// +optional
 	Integrity *bool `json:"integrity,omitempty"`
 
+	// IntegrityChecks holds the results of the recent integrity checks of this Repository, the most recent first.
+	// +optional
+	IntegrityChecks []IntegrityCheckResult `json:"integrityChecks,omitempty"`
+
+	// Verification summarises the verification results of the Snapshots of this Repository
+	// +optional
+	Verification *VerificationSummary `json:"verification,omitempty"`
+
 	// SnapshotCount specifies the number of current Snapshots stored in this Repository
 	// +optional
 	SnapshotCount *int32 `json:"snapshotCount,omitempty"`
@@ -106,6 +172,10 @@ type RepositoryStatus struct {
 	// +optional
 	Size string `json:"size,omitempty"`
 
+	// SizeBytes specifies the amount of backed up data stored in the Repository in bytes
+	// +optional
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
 	// RecentSnapshots holds a list of recent Snapshot information that has been taken in this Repository
 	// +optional
 	RecentSnapshots []SnapshotInfo `json:"recentSnapshots,omitempty"`
@@ -144,11 +214,85 @@ type SnapshotInfo struct {
 	// +optional
 	Size string `json:"size,omitempty"`
 
+	// SizeBytes represents the size of the Snapshot in bytes
+	// +optional
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
 	// SnapshotTime represents the time when this Snapshot was taken
 	// +optional
 	SnapshotTime *metav1.Time `json:"snapshotTime,omitempty"`
 }
 
+// VerificationSummary summarises the verification results of the Snapshots of a Repository
+type VerificationSummary struct {
+	// LastVerificationTime represents the timestamp of the most recent verification
+	// +optional
+	LastVerificationTime *metav1.Time `json:"lastVerificationTime,omitempty"`
+
+	// LastVerifiedSnapshot specifies the name of the most recently verified Snapshot
+	// +optional
+	LastVerifiedSnapshot string `json:"lastVerifiedSnapshot,omitempty"`
+
+	// LastStatus specifies the result of the most recent verification
+	// +optional
+	LastStatus VerificationStatus `json:"lastStatus,omitempty"`
+
+	// VerifiedSnapshots specifies the number of Snapshots whose last verification succeeded
+	// +optional
+	VerifiedSnapshots int32 `json:"verifiedSnapshots,omitempty"`
+
+	// FailedSnapshots specifies the number of Snapshots whose last verification failed
+	// +optional
+	FailedSnapshots int32 `json:"failedSnapshots,omitempty"`
+
+	// ConsecutiveFailures specifies the number of verifications that failed since the last successful one
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+}
+
+// IntegrityCheckResult specifies the result of an integrity check of the Repository
+type IntegrityCheckResult struct {
+	// StartTime represents the timestamp when the integrity check was started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime represents the timestamp when the integrity check was completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Duration specifies the time taken to complete the integrity check
+	// +optional
+	Duration string `json:"duration,omitempty"`
+
+	// DurationSeconds specifies the time taken to complete the integrity check in seconds
+	// +optional
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+
+	// Mode specifies what has been checked
+	// +optional
+	Mode IntegrityCheckMode `json:"mode,omitempty"`
+
+	// ReadDataPercentage specifies the percentage of the data that has been read back in "ReadData" mode
+	// +optional
+	ReadDataPercentage int32 `json:"readDataPercentage,omitempty"`
+
+	// Integrity specifies whether the check found the backed up data intact or not
+	// +optional
+	Integrity *bool `json:"integrity,omitempty"`
+
+	// ErrorsFound specifies the number of errors reported by the check
+	// +optional
+	ErrorsFound int64 `json:"errorsFound,omitempty"`
+
+	// RepairedPacks specifies the number of damaged packs that have been repaired after the check
+	// +optional
+	RepairedPacks int64 `json:"repairedPacks,omitempty"`
+
+	// Error specifies the reason in case the check could not be performed or found errors
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
 const (
 	TypeRepositoryInitialized               = "RepositoryInitialized"
 	ReasonRepositoryInitializationSucceeded = "RepositoryInitializationSucceeded"
@@ -20,11 +20,12 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
-	"strings"
+	"time"
 
 	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/crds"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"kmodules.xyz/client-go/apiextensions"
 	cutil "kmodules.xyz/client-go/conditions"
 	"kmodules.xyz/client-go/meta"
@@ -90,6 +91,19 @@ func (s *Snapshot) IsCompleted() bool {
 }
 
 func (s *Snapshot) GetIntegrity() *bool {
+	return s.getIntegrity(func(Component) bool { return true })
+}
+
+// GetIntegrityWithValidity returns the combined integrity of the components of the Snapshot like GetIntegrity.
+// In addition, the integrity is reported as unknown (nil) when any of the restic components
+// has not been checked within the validity period before now.
+func (s *Snapshot) GetIntegrityWithValidity(validity metav1.Duration, now time.Time) *bool {
+	return s.getIntegrity(func(c Component) bool {
+		return c.IntegrityCheckTime != nil && now.Sub(c.IntegrityCheckTime.Time) <= validity.Duration
+	})
+}
+
+func (s *Snapshot) getIntegrity(isRecent func(Component) bool) *bool {
 	if s.Status.Components == nil {
 		return nil
 	}
@@ -105,6 +119,10 @@ func (s *Snapshot) GetIntegrity() *bool {
 			continue
 		}
 
+		if !isRecent(component) {
+			return nil
+		}
+
 		hasResticComp = true
 		result = result && *component.Integrity
 	}
@@ -115,6 +133,113 @@ func (s *Snapshot) GetIntegrity() *bool {
 	return nil
 }
 
+// SetChain links the Snapshot to the chain of the previous Snapshot of the same session according to its type.
+// A full Snapshot starts a new chain. The previous Snapshot must be provided for an incremental Snapshot.
+func (s *Snapshot) SetChain(previous *Snapshot) error {
+	if s.Spec.Type != BackupTypeIncremental {
+		s.Spec.Chain = nil
+		return nil
+	}
+	if previous == nil {
+		return fmt.Errorf("incremental snapshot %s/%s requires a previous snapshot", s.Namespace, s.Name)
+	}
+
+	chain := &SnapshotChain{
+		BaseSnapshot:     previous.Name,
+		BaseSnapshotTime: previous.Status.SnapshotTime,
+		ParentSnapshot:   previous.Name,
+		Length:           1,
+	}
+	if previous.Spec.Type == BackupTypeIncremental && previous.Spec.Chain != nil {
+		chain.BaseSnapshot = previous.Spec.Chain.BaseSnapshot
+		chain.BaseSnapshotTime = previous.Spec.Chain.BaseSnapshotTime
+		chain.Length = previous.Spec.Chain.Length + 1
+	}
+	s.Spec.Chain = chain
+	return nil
+}
+
+// GetChainLength returns the number of incremental Snapshots in the chain up to and including this one.
+func (s *Snapshot) GetChainLength() int32 {
+	if s.Spec.Type != BackupTypeIncremental || s.Spec.Chain == nil {
+		return 0
+	}
+	return s.Spec.Chain.Length
+}
+
+// GetFullBackupTime returns when the full Snapshot of the chain of this Snapshot was taken.
+func (s *Snapshot) GetFullBackupTime() *metav1.Time {
+	if s.Spec.Type != BackupTypeIncremental {
+		return s.Status.SnapshotTime
+	}
+	if s.Spec.Chain == nil {
+		return nil
+	}
+	return s.Spec.Chain.BaseSnapshotTime
+}
+
+// FilterPrunableSnapshots splits the Snapshots selected for pruning into the ones that can be deleted safely
+// and the ones that must be kept because a remaining Snapshot depends on them. All the Snapshots of the
+// Repository must be provided so that the dependency of the remaining incremental Snapshots can be resolved.
+func FilterPrunableSnapshots(candidates, all []Snapshot) ([]Snapshot, []Snapshot) {
+	pruning := make(map[string]bool, len(candidates))
+	for _, snap := range candidates {
+		pruning[snap.Name] = true
+	}
+
+	// a kept Snapshot protects its dependencies, which in turn protect their own dependencies
+	protected := make(map[string]bool)
+	changed := true
+	for changed {
+		changed = false
+		for _, snap := range all {
+			if (pruning[snap.Name] && !protected[snap.Name]) || snap.Spec.Chain == nil {
+				continue
+			}
+			for _, dep := range []string{snap.Spec.Chain.BaseSnapshot, snap.Spec.Chain.ParentSnapshot} {
+				if dep != "" && pruning[dep] && !protected[dep] {
+					protected[dep] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	var prunable, kept []Snapshot
+	for _, snap := range candidates {
+		if protected[snap.Name] {
+			kept = append(kept, snap)
+		} else {
+			prunable = append(prunable, snap)
+		}
+	}
+	return prunable, kept
+}
+
+// SetIntegrity records the result of an integrity check for all the restic components of the Snapshot.
+// The validity is the result validity of the integrity check policy of the Repository, if any.
+func (s *Snapshot) SetIntegrity(integrity bool, checkTime metav1.Time, validity *metav1.Duration) {
+	for name, component := range s.Status.Components {
+		if component.Driver != apis.DriverRestic {
+			continue
+		}
+		component.Integrity = &integrity
+		component.IntegrityCheckTime = &checkTime
+		s.Status.Components[name] = component
+	}
+	s.RefreshIntegrity(validity, checkTime.Time)
+}
+
+// RefreshIntegrity updates the integrity of the Snapshot at the given time. The integrity is reported
+// as unknown once any of the checks of the restic components is older than the validity, if any.
+func (s *Snapshot) RefreshIntegrity(validity *metav1.Duration, now time.Time) {
+	if validity == nil {
+		s.Status.Integrity = s.GetIntegrity()
+		return
+	}
+	s.Status.Integrity = s.GetIntegrityWithValidity(*validity, now)
+}
+
 func (s *Snapshot) GetTotalBackupSizeInBytes() (uint64, error) {
 	if s.Status.Components == nil {
 		return 0, fmt.Errorf("no component found for snapshot %s/%s", s.Namespace, s.Name)
@@ -127,14 +252,9 @@ func (s *Snapshot) GetTotalBackupSizeInBytes() (uint64, error) {
 				return 0, fmt.Errorf("resticStats size of component %s is empty for the snapshot %s/%s", componentName, s.Namespace, s.Name)
 			}
 
-			sizeWithUnit := strings.Split(component.Size, " ")
-			if len(sizeWithUnit) < 2 {
-				return 0, fmt.Errorf("resticStats size of component %s is invalid for the snapshot %s/%s", componentName, s.Namespace, s.Name)
-			}
-
-			sizeInByte, err := ConvertSizeToByte(sizeWithUnit)
+			sizeInByte, err := component.GetSizeInBytes()
 			if err != nil {
-				return 0, err
+				return 0, fmt.Errorf("resticStats size of component %s is invalid for the snapshot %s/%s: %w", componentName, s.Namespace, s.Name, err)
 			}
 			totalSizeInByte += sizeInByte
 		}
@@ -142,40 +262,116 @@ func (s *Snapshot) GetTotalBackupSizeInBytes() (uint64, error) {
 	return totalSizeInByte, nil
 }
 
-func (s *Snapshot) GetSize() string {
+// GetSizeInBytes returns the total size of the components of this Snapshot in bytes.
+// The second return value is false if the size of any restic component is not known yet.
+func (s *Snapshot) GetSizeInBytes() (uint64, bool) {
 	if s.Status.Components == nil {
-		return ""
+		return 0, false
 	}
 
 	var totalSizeInByte uint64
 	hasResticComp := false
 	for _, component := range s.Status.Components {
-		if component.ResticStats != nil &&
-			component.Size == "" {
-			return ""
-		}
-
-		if component.Size == "" {
+		if !component.hasSize() {
+			if component.ResticStats != nil {
+				return 0, false
+			}
 			continue
 		}
 
-		sizeWithUnit := strings.Split(component.Size, " ")
-		if len(sizeWithUnit) < 2 {
-			return ""
-		}
-
-		sizeInByte, err := ConvertSizeToByte(sizeWithUnit)
+		sizeInByte, err := component.GetSizeInBytes()
 		if err != nil {
-			return ""
+			return 0, false
 		}
 		hasResticComp = true
 		totalSizeInByte += sizeInByte
 	}
-	if hasResticComp {
-		return FormatBytes(totalSizeInByte)
+	return totalSizeInByte, hasResticComp
+}
+
+func (s *Snapshot) GetSize() string {
+	totalSizeInByte, ok := s.GetSizeInBytes()
+	if !ok {
+		return ""
+	}
+	return FormatBytes(totalSizeInByte)
+}
+
+// SetSize sets both the human-readable and the numeric size of the Snapshot from the size of its components.
+// The size is left unchanged if the size of any restic component is not known yet.
+func (s *Snapshot) SetSize() {
+	totalSizeInByte, ok := s.GetSizeInBytes()
+	if !ok {
+		return
+	}
+	s.Status.Size = FormatBytes(totalSizeInByte)
+	s.Status.SizeBytes = int64(totalSizeInByte)
+}
+
+// SetSize sets both the human-readable and the numeric size of the component.
+func (c *Component) SetSize(sizeInByte uint64) {
+	c.Size = FormatBytes(sizeInByte)
+	c.SizeBytes = int64(sizeInByte)
+}
+
+// SetDuration sets both the human-readable and the numeric duration of the component.
+func (c *Component) SetDuration(d time.Duration) {
+	c.Duration = d.String()
+	c.DurationSeconds = int64(d.Seconds())
+}
+
+// GetSizeInBytes returns the size of the component in bytes. It falls back to parsing the
+// human-readable Size for the components that were reported before SizeBytes was introduced.
+func (c Component) GetSizeInBytes() (uint64, error) {
+	if c.SizeBytes > 0 {
+		return uint64(c.SizeBytes), nil
+	}
+	return ParseBytes(c.Size)
+}
+
+// GetDataSizeInBytes returns the size of the backed up data of the component in bytes, i.e. the size of the data
+// once it is restored, as opposed to the deduplicated and compressed size in the repository.
+// The second return value is false if the size is not known for any of the restic snapshots of the component.
+func (c Component) GetDataSizeInBytes() (uint64, bool) {
+	if len(c.ResticStats) == 0 {
+		return 0, false
+	}
+
+	var total uint64
+	for _, stats := range c.ResticStats {
+		switch {
+		case stats.Progress != nil && stats.Progress.TotalBytes > 0:
+			total += uint64(stats.Progress.TotalBytes)
+		case stats.Summary != nil && stats.Summary.Size != "":
+			size, err := ParseBytes(stats.Summary.Size)
+			if err != nil {
+				return 0, false
+			}
+			total += size
+		default:
+			return 0, false
+		}
+	}
+	return total, true
+}
+
+// GetVolumeSnapshots returns the VolumeSnapshots of the component indexed by the name of their source PVC.
+// It includes the VolumeSnapshots taken individually as well as the members of the VolumeGroupSnapshot.
+func (c Component) GetVolumeSnapshots() map[string]VolumeSnapshotterStats {
+	snapshots := make(map[string]VolumeSnapshotterStats)
+	for _, vs := range c.VolumeSnapshotterStats {
+		snapshots[vs.PVCName] = vs
+	}
+	if c.VolumeGroupSnapshotStats != nil {
+		for _, vs := range c.VolumeGroupSnapshotStats.Volumes {
+			snapshots[vs.PVCName] = vs
+		}
 	}
+	return snapshots
+}
 
-	return ""
+func (c Component) hasSize() bool {
+	return c.SizeBytes > 0 || c.Size != ""
 }
 
 func GenerateSnapshotName(repoName, backupSession string) string {
@@ -202,3 +398,24 @@ func (s *Snapshot) OffshootLabels() map[string]string {
 func (s *Snapshot) GetComponentPath(componentName string) string {
 	return filepath.Join(apis.DirRepository, s.Spec.Version, s.Spec.Session, componentName)
 }
+
+// IsPaused returns whether the Snapshot is paused at the given time
+func (s *Snapshot) IsPaused(now time.Time) bool {
+	return apis.IsPaused(s.Spec.Paused, s.Spec.PauseInfo, now)
+}
+
+// SetPausedCondition reflects the pause state of the Snapshot at the given time in its conditions
+func (s *Snapshot) SetPausedCondition(now time.Time) {
+	s.Status.Conditions = apis.SetPausedCondition(s.Status.Conditions, s.Spec.Paused, s.Spec.PauseInfo, now)
+}
+
+// RecordVerification records the result of a verification at the beginning of the verification history
+// and updates the verification status of the Snapshot accordingly.
+func (s *Snapshot) RecordVerification(result VerificationResult) {
+	s.Status.VerificationStatus = result.Status
+	s.Status.VerificationSession = result.Session
+	s.Status.VerificationHistory = append([]VerificationResult{result}, s.Status.VerificationHistory...)
+	if len(s.Status.VerificationHistory) > DefaultVerificationHistoryLimit {
+		s.Status.VerificationHistory = s.Status.VerificationHistory[:DefaultVerificationHistoryLimit]
+	}
+}
@@ -18,8 +18,13 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestSnapshotComponentsPhase(t *testing.T) {
@@ -80,6 +85,151 @@ func TestSnapshotComponentsPhase(t *testing.T) {
 	}
 }
 
+func TestSnapshotSize(t *testing.T) {
+	tests := []struct {
+		name         string
+		snapshot     *Snapshot
+		expectedSize uint64
+		expectedOk   bool
+	}{
+		{
+			name:         "Size should be unknown if no component is initialized",
+			snapshot:     sampleSnapshot(1, nil),
+			expectedSize: 0,
+			expectedOk:   false,
+		},
+		{
+			name: "Size should be unknown if any restic component has not reported its size",
+			snapshot: sampleSnapshot(2, map[string]Component{
+				"manifest": {SizeBytes: 1024},
+				"dump":     {ResticStats: []ResticStats{{}}},
+			}),
+			expectedSize: 0,
+			expectedOk:   false,
+		},
+		{
+			name: "Size should be calculated from the numeric sizes",
+			snapshot: sampleSnapshot(2, map[string]Component{
+				"manifest": {Size: "1.000 KiB", SizeBytes: 1024},
+				"dump":     {Size: "2.000 MiB", SizeBytes: 2 << 20},
+			}),
+			expectedSize: 1024 + 2<<20,
+			expectedOk:   true,
+		},
+		{
+			name: "Size should fall back to the formatted sizes if the numeric sizes are missing",
+			snapshot: sampleSnapshot(2, map[string]Component{
+				"manifest": {Size: "1.000 KiB"},
+				"dump":     {SizeBytes: 2 << 20},
+			}),
+			expectedSize: 1024 + 2<<20,
+			expectedOk:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			size, ok := test.snapshot.GetSizeInBytes()
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedSize, size)
+
+			test.snapshot.SetSize()
+			assert.Equal(t, int64(test.expectedSize), test.snapshot.Status.SizeBytes)
+			assert.Equal(t, test.snapshot.GetSize(), test.snapshot.Status.Size)
+		})
+	}
+}
+
+func TestSnapshotIntegrity(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	validity := &metav1.Duration{Duration: 24 * time.Hour}
+	recent := metav1.NewTime(now.Add(-time.Hour))
+	stale := metav1.NewTime(now.Add(-48 * time.Hour))
+
+	tests := []struct {
+		name       string
+		components map[string]Component
+		validity   *metav1.Duration
+		expected   *bool
+	}{
+		{
+			name: "Integrity should be unknown if a restic component has not been checked",
+			components: map[string]Component{
+				"dump": {Driver: apis.DriverRestic, ResticStats: []ResticStats{{}}},
+			},
+			expected: nil,
+		},
+		{
+			name: "Integrity should ignore the check time if no validity is specified",
+			components: map[string]Component{
+				"dump": {Driver: apis.DriverRestic, Integrity: ptr.To(true), IntegrityCheckTime: &stale},
+			},
+			expected: ptr.To(true),
+		},
+		{
+			name: "Integrity should be false if any recently checked component is corrupted",
+			components: map[string]Component{
+				"dump":     {Driver: apis.DriverRestic, Integrity: ptr.To(true), IntegrityCheckTime: &recent},
+				"manifest": {Driver: apis.DriverRestic, Integrity: ptr.To(false), IntegrityCheckTime: &recent},
+			},
+			validity: validity,
+			expected: ptr.To(false),
+		},
+		{
+			name: "Integrity should be unknown if the last check is older than the validity",
+			components: map[string]Component{
+				"dump":     {Driver: apis.DriverRestic, Integrity: ptr.To(true), IntegrityCheckTime: &recent},
+				"manifest": {Driver: apis.DriverRestic, Integrity: ptr.To(true), IntegrityCheckTime: &stale},
+			},
+			validity: validity,
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snap := sampleSnapshot(int32(len(test.components)), test.components)
+			if test.validity == nil {
+				assert.Equal(t, test.expected, snap.GetIntegrity())
+			} else {
+				assert.Equal(t, test.expected, snap.GetIntegrityWithValidity(*test.validity, now))
+			}
+		})
+	}
+}
+
+func TestFilterPrunableSnapshots(t *testing.T) {
+	full1 := Snapshot{ObjectMeta: metav1.ObjectMeta{Name: "full-1"}, Spec: SnapshotSpec{Type: BackupTypeFull}}
+	inc1 := Snapshot{ObjectMeta: metav1.ObjectMeta{Name: "inc-1"}, Spec: SnapshotSpec{Type: BackupTypeIncremental}}
+	assert.Nil(t, inc1.SetChain(&full1))
+	inc2 := Snapshot{ObjectMeta: metav1.ObjectMeta{Name: "inc-2"}, Spec: SnapshotSpec{Type: BackupTypeIncremental}}
+	assert.Nil(t, inc2.SetChain(&inc1))
+	full2 := Snapshot{ObjectMeta: metav1.ObjectMeta{Name: "full-2"}, Spec: SnapshotSpec{Type: BackupTypeFull}}
+	assert.Nil(t, full2.SetChain(&inc2))
+
+	assert.Equal(t, int32(2), inc2.GetChainLength())
+	assert.Equal(t, "full-1", inc2.Spec.Chain.BaseSnapshot)
+	assert.Nil(t, full2.Spec.Chain)
+
+	all := []Snapshot{full1, inc1, inc2, full2}
+
+	prunable, kept := FilterPrunableSnapshots([]Snapshot{full1, inc1}, all)
+	assert.Empty(t, prunable)
+	assert.Equal(t, []string{"full-1", "inc-1"}, snapshotNames(kept))
+
+	prunable, kept = FilterPrunableSnapshots([]Snapshot{full1, inc1, inc2}, all)
+	assert.Equal(t, []string{"full-1", "inc-1", "inc-2"}, snapshotNames(prunable))
+	assert.Empty(t, kept)
+}
+
+func snapshotNames(snapshots []Snapshot) []string {
+	var names []string
+	for _, s := range snapshots {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
 // nolint:unparam
 func sampleSnapshot(totalComponents int32, components map[string]Component) *Snapshot {
 	return &Snapshot{
@@ -70,6 +70,10 @@ type SnapshotSpec struct {
 	// Type specifies whether this snapshot represents a full or incremental backup
 	Type BackupType `json:"type,omitempty"`
 
+	// Chain specifies the position of this Snapshot in the chain of incremental backups
+	// +optional
+	Chain *SnapshotChain `json:"chain,omitempty"`
+
 	// Repository specifies the name of the Repository where this Snapshot is being stored.
 	Repository string `json:"repository,omitempty"`
 
@@ -98,6 +102,33 @@ type SnapshotSpec struct {
 	// KubeStash will not process any further event for the Snapshot.
 	// +optional
 	Paused bool `json:"paused,omitempty"`
+
+	// PauseInfo specifies the details of the pause, i.e. why and by whom the Snapshot was paused and when it should be resumed.
+	// It is only effective when `paused` is set to `true`.
+	// +optional
+	PauseInfo *apis.PauseInfo `json:"pauseInfo,omitempty"`
+}
+
+// SnapshotChain specifies the Snapshots an incremental Snapshot depends on
+type SnapshotChain struct {
+	// BaseSnapshot specifies the name of the full Snapshot the chain starts from.
+	// It is empty for a full Snapshot.
+	// +optional
+	BaseSnapshot string `json:"baseSnapshot,omitempty"`
+
+	// BaseSnapshotTime specifies when the full Snapshot the chain starts from was taken
+	// +optional
+	BaseSnapshotTime *metav1.Time `json:"baseSnapshotTime,omitempty"`
+
+	// ParentSnapshot specifies the name of the Snapshot this incremental Snapshot was taken on top of.
+	// It is empty for a full Snapshot.
+	// +optional
+	ParentSnapshot string `json:"parentSnapshot,omitempty"`
+
+	// Length specifies the number of incremental Snapshots in the chain up to and including this one.
+	// It is 0 for a full Snapshot.
+	// +optional
+	Length int32 `json:"length,omitempty"`
 }
 
 // SnapshotStatus defines the observed state of Snapshot
@@ -114,6 +145,10 @@ type SnapshotStatus struct {
 	// +optional
 	VerificationSession string `json:"verificationSession,omitempty"`
 
+	// VerificationHistory holds the results of the recent verifications of this Snapshot, the most recent first.
+	// +optional
+	VerificationHistory []VerificationResult `json:"verificationHistory,omitempty"`
+
 	// SnapshotTime represents the timestamp when this Snapshot was taken.
 	// +optional
 	SnapshotTime *metav1.Time `json:"snapshotTime,omitempty"`
@@ -126,6 +161,10 @@ type SnapshotStatus struct {
 	// +optional
 	Size string `json:"size,omitempty"`
 
+	// SizeBytes represents the size of the Snapshot in bytes
+	// +optional
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
 	// Integrity represents whether the Snapshot data has been corrupted or not
 	// +optional
 	Integrity *bool `json:"integrity,omitempty"`
@@ -165,6 +204,28 @@ const (
 	SnapshotVerificationFailed VerificationStatus = "VerificationFailed"
 )
 
+// DefaultVerificationHistoryLimit specifies how many verification results are kept in the Snapshot status
+const DefaultVerificationHistoryLimit = 5
+
+// VerificationResult specifies the result of a verification of the Snapshot
+type VerificationResult struct {
+	// Session specifies the name of the BackupVerificationSession that verified the Snapshot
+	// +optional
+	Session string `json:"session,omitempty"`
+
+	// Status specifies whether the verification succeeded or not
+	// +optional
+	Status VerificationStatus `json:"status,omitempty"`
+
+	// VerificationTime represents the timestamp when the verification was completed
+	// +optional
+	VerificationTime *metav1.Time `json:"verificationTime,omitempty"`
+
+	// Reason specifies the reason if the verification failed
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
 // Component represents the backup information of individual components
 type Component struct {
 	// Path specifies the path inside the Repository where the backed up data for this component has been stored.
@@ -179,14 +240,26 @@ type Component struct {
 	// +optional
 	Size string `json:"size,omitempty"`
 
+	// SizeBytes represents the size of the restic repository for this component in bytes
+	// +optional
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
 	// Duration specifies the total time taken to complete the backup process for this component
 	// +optional
 	Duration string `json:"duration,omitempty"`
 
+	// DurationSeconds specifies the total time taken to complete the backup process for this component in seconds
+	// +optional
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+
 	// Integrity represents the result of the restic repository integrity check for this component
 	// +optional
 	Integrity *bool `json:"integrity,omitempty"`
 
+	// IntegrityCheckTime represents the timestamp when the integrity of this component was last checked
+	// +optional
+	IntegrityCheckTime *metav1.Time `json:"integrityCheckTime,omitempty"`
+
 	// Error specifies the reason in case of backup failure for the component
 	// +optional
 	Error string `json:"error,omitempty"`
@@ -214,6 +287,11 @@ type Component struct {
 	// +optional
 	VolumeSnapshotterStats []VolumeSnapshotterStats `json:"volumeSnapshotterStats,omitempty"`
 
+	// VolumeGroupSnapshotStats specifies the information of the VolumeGroupSnapshot taken by the "VolumeSnapshotter" driver
+	// when the volumes of the target are snapshotted together.
+	// +optional
+	VolumeGroupSnapshotStats *VolumeGroupSnapshotStats `json:"volumeGroupSnapshotStats,omitempty"`
+
 	LogStats *LogStats `json:"logStats,omitempty"`
 
 	// ClickHouseStats specifies the ClickHouse Backup specific information
@@ -351,13 +429,25 @@ type BackupProgress struct {
 	// +optional
 	BackupDone string `json:"backupDone,omitempty"`
 
+	// BackupDoneBytes represents the amount of data in bytes that has been backup so far
+	// +optional
+	BackupDoneBytes int64 `json:"backupDoneBytes,omitempty"`
+
 	// Total represents the total amount of data that needs to be transferred during the backup
 	// +optional
 	Total string `json:"total,omitempty"`
 
+	// TotalBytes represents the total amount of data in bytes that needs to be transferred during the backup
+	// +optional
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+
 	// Speed represents the transfer speed during the backup
 	// +optional
 	Speed string `json:"speed,omitempty"`
+
+	// SpeedBytesPerSecond represents the transfer speed during the backup in bytes per second
+	// +optional
+	SpeedBytesPerSecond int64 `json:"speedBytesPerSecond,omitempty"`
 }
 
 // VolumeSnapshotterStats specifies the "VolumeSnapshotter" driver specific information
@@ -378,6 +468,24 @@ type VolumeSnapshotterStats struct {
 	VolumeSnapshotTime *metav1.Time `json:"volumeSnapshotTime,omitempty"`
 }
 
+// VolumeGroupSnapshotStats specifies the information of a VolumeGroupSnapshot taken by the "VolumeSnapshotter" driver
+type VolumeGroupSnapshotStats struct {
+	// Name represents the name of the created VolumeGroupSnapshot.
+	Name string `json:"name,omitempty"`
+
+	// VolumeGroupSnapshotClassName represents the name of the VolumeGroupSnapshotClass used to create the VolumeGroupSnapshot.
+	// +optional
+	VolumeGroupSnapshotClassName string `json:"volumeGroupSnapshotClassName,omitempty"`
+
+	// CreationTime indicates the timestamp at which the volumes of the group were snapshotted.
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// Volumes represents the VolumeSnapshots created for the individual PVCs of the group.
+	// +optional
+	Volumes []VolumeSnapshotterStats `json:"volumes,omitempty"`
+}
+
 // WalGStats specifies the information specific to the "WalG" driver.
 type WalGStats struct {
 	// Id represents the WalG snapshot ID.
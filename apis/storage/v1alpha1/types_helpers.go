@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"gomodules.xyz/x/filepath"
 	core "k8s.io/api/core/v1"
@@ -65,6 +66,15 @@ func ConvertSizeToByte(sizeWithUnit []string) (uint64, error) {
 	}
 }
 
+// ParseBytes converts a human-readable size formatted by FormatBytes (i.e. "1.500 MiB") into bytes.
+func ParseBytes(size string) (uint64, error) {
+	sizeWithUnit := strings.Split(size, " ")
+	if len(sizeWithUnit) < 2 {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+	return ConvertSizeToByte(sizeWithUnit)
+}
+
 func FormatBytes(c uint64) string {
 	b := float64(c)
 	switch {
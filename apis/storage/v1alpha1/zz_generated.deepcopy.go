@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"kmodules.xyz/client-go/api/v1"
 	"kubestash.dev/apimachinery/apis"
@@ -232,6 +233,10 @@ func (in *Component) DeepCopyInto(out *Component) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IntegrityCheckTime != nil {
+		in, out := &in.IntegrityCheckTime, &out.IntegrityCheckTime
+		*out = (*in).DeepCopy()
+	}
 	if in.ResticStats != nil {
 		in, out := &in.ResticStats, &out.ResticStats
 		*out = make([]ResticStats, len(*in))
@@ -261,6 +266,11 @@ func (in *Component) DeepCopyInto(out *Component) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.VolumeGroupSnapshotStats != nil {
+		in, out := &in.VolumeGroupSnapshotStats, &out.VolumeGroupSnapshotStats
+		*out = new(VolumeGroupSnapshotStats)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LogStats != nil {
 		in, out := &in.LogStats, &out.LogStats
 		*out = new(LogStats)
@@ -342,6 +352,64 @@ func (in *GCSSpec) DeepCopy() *GCSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrityCheckPolicy) DeepCopyInto(out *IntegrityCheckPolicy) {
+	*out = *in
+	if in.ReadDataPercentage != nil {
+		in, out := &in.ReadDataPercentage, &out.ReadDataPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ResultValidity != nil {
+		in, out := &in.ResultValidity, &out.ResultValidity
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrityCheckPolicy.
+func (in *IntegrityCheckPolicy) DeepCopy() *IntegrityCheckPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrityCheckPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrityCheckResult) DeepCopyInto(out *IntegrityCheckResult) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Integrity != nil {
+		in, out := &in.Integrity, &out.Integrity
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrityCheckResult.
+func (in *IntegrityCheckResult) DeepCopy() *IntegrityCheckResult {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrityCheckResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalSpec) DeepCopyInto(out *LocalSpec) {
 	*out = *in
@@ -598,6 +666,16 @@ func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 		*out = new(v1.ObjectReference)
 		**out = **in
 	}
+	if in.PauseInfo != nil {
+		in, out := &in.PauseInfo, &out.PauseInfo
+		*out = new(apis.PauseInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IntegrityCheck != nil {
+		in, out := &in.IntegrityCheck, &out.IntegrityCheck
+		*out = new(IntegrityCheckPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositorySpec.
@@ -622,6 +700,18 @@ func (in *RepositoryStatus) DeepCopyInto(out *RepositoryStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IntegrityChecks != nil {
+		in, out := &in.IntegrityChecks, &out.IntegrityChecks
+		*out = make([]IntegrityCheckResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(VerificationSummary)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SnapshotCount != nil {
 		in, out := &in.SnapshotCount, &out.SnapshotCount
 		*out = new(int32)
@@ -814,7 +904,7 @@ func (in *Snapshot) DeepCopyInto(out *Snapshot) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -836,6 +926,25 @@ func (in *Snapshot) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotChain) DeepCopyInto(out *SnapshotChain) {
+	*out = *in
+	if in.BaseSnapshotTime != nil {
+		in, out := &in.BaseSnapshotTime, &out.BaseSnapshotTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotChain.
+func (in *SnapshotChain) DeepCopy() *SnapshotChain {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotChain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotInfo) DeepCopyInto(out *SnapshotInfo) {
 	*out = *in
@@ -890,7 +999,17 @@ func (in *SnapshotList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotSpec) DeepCopyInto(out *SnapshotSpec) {
 	*out = *in
+	if in.Chain != nil {
+		in, out := &in.Chain, &out.Chain
+		*out = new(SnapshotChain)
+		(*in).DeepCopyInto(*out)
+	}
 	out.AppRef = in.AppRef
+	if in.PauseInfo != nil {
+		in, out := &in.PauseInfo, &out.PauseInfo
+		*out = new(apis.PauseInfo)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotSpec.
@@ -906,6 +1025,13 @@ func (in *SnapshotSpec) DeepCopy() *SnapshotSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotStatus) DeepCopyInto(out *SnapshotStatus) {
 	*out = *in
+	if in.VerificationHistory != nil {
+		in, out := &in.VerificationHistory, &out.VerificationHistory
+		*out = make([]VerificationResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SnapshotTime != nil {
 		in, out := &in.SnapshotTime, &out.SnapshotTime
 		*out = (*in).DeepCopy()
@@ -1005,6 +1131,70 @@ func (in *SuccessfulSnapshotsKeepPolicy) DeepCopy() *SuccessfulSnapshotsKeepPoli
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationResult) DeepCopyInto(out *VerificationResult) {
+	*out = *in
+	if in.VerificationTime != nil {
+		in, out := &in.VerificationTime, &out.VerificationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationResult.
+func (in *VerificationResult) DeepCopy() *VerificationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationSummary) DeepCopyInto(out *VerificationSummary) {
+	*out = *in
+	if in.LastVerificationTime != nil {
+		in, out := &in.LastVerificationTime, &out.LastVerificationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationSummary.
+func (in *VerificationSummary) DeepCopy() *VerificationSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupSnapshotStats) DeepCopyInto(out *VolumeGroupSnapshotStats) {
+	*out = *in
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VolumeSnapshotterStats, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSnapshotStats.
+func (in *VolumeGroupSnapshotStats) DeepCopy() *VolumeGroupSnapshotStats {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupSnapshotStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshotterStats) DeepCopyInto(out *VolumeSnapshotterStats) {
 	*out = *in
@@ -63,8 +63,38 @@ type ParameterDefinition struct {
 	// Default specifies a default value for the parameter
 	// +optional
 	Default string `json:"default,omitempty"`
+
+	// Type specifies the type of the value of the parameter.
+	// Valid values are:
+	// - "String": The value can be any string. This is the default type.
+	// - "Integer": The value must be an integer.
+	// - "Boolean": The value must be either "true" or "false".
+	// - "Enum": The value must be one of the values specified in the `enum` field.
+	// - "SecretRef": The value must be the name of a Secret in the namespace of the referring resource.
+	// +optional
+	Type ParameterType `json:"type,omitempty"`
+
+	// Enum specifies the allowed values of an "Enum" type parameter
+	// +optional
+	Enum []string `json:"enum,omitempty"`
+
+	// Pattern specifies a regular expression that the value of the parameter must match
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
 }
 
+// ParameterType specifies the type of the value of a parameter
+// +kubebuilder:validation:Enum=String;Integer;Boolean;Enum;SecretRef
+type ParameterType string
+
+const (
+	ParameterTypeString    ParameterType = "String"
+	ParameterTypeInteger   ParameterType = "Integer"
+	ParameterTypeBoolean   ParameterType = "Boolean"
+	ParameterTypeEnum      ParameterType = "Enum"
+	ParameterTypeSecretRef ParameterType = "SecretRef"
+)
+
 // UsagePolicy specifies a policy that restrict the usage of a resource across namespaces.
 // +k8s:openapi-gen=true
 type UsagePolicy struct {
@@ -108,3 +138,31 @@ const (
 	// NamespacesFromSame specifies that only the current namespace can use the resource.
 	NamespacesFromSame FromNamespaces = "Same"
 )
+
+// PauseInfo specifies the details of a pause
+// +k8s:openapi-gen=true
+type PauseInfo struct {
+	// Reason specifies why the resource has been paused
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// PausedBy specifies the user who paused the resource.
+	// It is set by the webhook from the user of the request that paused the resource. Any value specified by the client is overwritten.
+	// +optional
+	PausedBy string `json:"pausedBy,omitempty"`
+
+	// PausedAt specifies when the resource was paused.
+	// It is set by the webhook when the resource is paused. Any value specified by the client is overwritten.
+	// +optional
+	PausedAt *metav1.Time `json:"pausedAt,omitempty"`
+
+	// ResumeAt specifies when the resource should be resumed automatically.
+	// If not specified, the resource stays paused until it is resumed manually.
+	// +optional
+	ResumeAt *metav1.Time `json:"resumeAt,omitempty"`
+
+	// Sessions specifies the names of the sessions to pause. It is only applicable to the backup invokers.
+	// If not specified, all the sessions are paused.
+	// +optional
+	Sessions []string `json:"sessions,omitempty"`
+}
@@ -53,6 +53,11 @@ func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParameterDefinition) DeepCopyInto(out *ParameterDefinition) {
 	*out = *in
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterDefinition.
@@ -65,6 +70,34 @@ func (in *ParameterDefinition) DeepCopy() *ParameterDefinition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseInfo) DeepCopyInto(out *PauseInfo) {
+	*out = *in
+	if in.PausedAt != nil {
+		in, out := &in.PausedAt, &out.PausedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ResumeAt != nil {
+		in, out := &in.ResumeAt, &out.ResumeAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Sessions != nil {
+		in, out := &in.Sessions, &out.Sessions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseInfo.
+func (in *PauseInfo) DeepCopy() *PauseInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UsagePolicy) DeepCopyInto(out *UsagePolicy) {
 	*out = *in
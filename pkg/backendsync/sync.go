@@ -0,0 +1,306 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"kubestash.dev/apimachinery/apis"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+	"kubestash.dev/apimachinery/pkg/blob"
+	"kubestash.dev/apimachinery/pkg/catalog"
+
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	kmc "kmodules.xyz/client-go/client"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ConflictPolicy specifies what to do when an object read from the backend already exists in the cluster
+type ConflictPolicy string
+
+const (
+	// ConflictPolicySkip keeps the existing object untouched
+	ConflictPolicySkip ConflictPolicy = "Skip"
+	// ConflictPolicyOverwrite replaces the spec and status of the existing object with the ones read from the backend
+	ConflictPolicyOverwrite ConflictPolicy = "Overwrite"
+)
+
+// Options specifies how the cluster state should be reconstructed from the backend
+type Options struct {
+	// StorageRef refers to the BackupStorage the backend belongs to.
+	// It is used as the storage reference of the Repositories that don't have their metadata stored in the backend.
+	StorageRef kmapi.ObjectReference
+	// ConflictPolicy specifies what to do with the objects that already exist in the cluster.
+	// The default is ConflictPolicySkip.
+	ConflictPolicy ConflictPolicy
+	// DryRun reports what would be done without creating or updating any object
+	DryRun bool
+}
+
+// Report holds the outcome of the sync
+type Report struct {
+	Created []kmapi.TypedObjectReference
+	Updated []kmapi.TypedObjectReference
+	Skipped []kmapi.TypedObjectReference
+	// Errors holds the metadata that could not be read and the objects that could not be synced
+	Errors []error
+}
+
+// Syncer reconstructs the Repository and Snapshot CRs from the metadata stored in a backend
+type Syncer struct {
+	client client.Client
+	blob   *blob.Blob
+	opts   Options
+}
+
+func NewSyncer(c client.Client, b *blob.Blob, opts Options) *Syncer {
+	if opts.ConflictPolicy == "" {
+		opts.ConflictPolicy = ConflictPolicySkip
+	}
+	return &Syncer{
+		client: c,
+		blob:   b,
+		opts:   opts,
+	}
+}
+
+// Sync walks through the Repositories recorded in the BackendMeta of the backend and
+// creates the respective Repository and Snapshot CRs along with their status.
+// The returned error is the aggregation of the errors recorded in the Report.
+func (s *Syncer) Sync(ctx context.Context) (*Report, error) {
+	meta, err := catalog.LoadBackendMeta(ctx, s.blob)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	for _, info := range meta.Repositories {
+		s.syncRepository(ctx, info, report)
+	}
+	return report, errors.Join(report.Errors...)
+}
+
+func (s *Syncer) syncRepository(ctx context.Context, info storageapi.RepositoryInfo, report *Report) {
+	snapshots, err := catalog.LoadSnapshots(ctx, s.blob, info)
+	if err != nil {
+		report.Errors = append(report.Errors, err)
+	}
+
+	repo, err := s.loadRepository(ctx, info)
+	if err != nil {
+		report.Errors = append(report.Errors, err)
+		return
+	}
+	if len(repo.Status.RecentSnapshots) == 0 {
+		setRepositoryStatusFromSnapshots(repo, snapshots)
+	}
+
+	if err := s.syncObject(ctx, repo, report); err != nil {
+		report.Errors = append(report.Errors, fmt.Errorf("failed to sync repository %s/%s: %w", repo.Namespace, repo.Name, err))
+		return
+	}
+
+	for i := range snapshots {
+		snap := &snapshots[i]
+		cleanupObjectMeta(&snap.ObjectMeta)
+		if err := s.syncObject(ctx, snap, report); err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("failed to sync snapshot %s/%s: %w", snap.Namespace, snap.Name, err))
+		}
+	}
+}
+
+// loadRepository reads the Repository metadata stored in the backend. If the metadata is not
+// available, the Repository is reconstructed from the information recorded in the BackendMeta.
+func (s *Syncer) loadRepository(ctx context.Context, info storageapi.RepositoryInfo) (*storageapi.Repository, error) {
+	repo := &storageapi.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.Name,
+			Namespace: info.Namespace,
+		},
+		Spec: storageapi.RepositorySpec{
+			StorageRef: s.opts.StorageRef,
+			Path:       info.Path,
+		},
+	}
+	if size, err := storageapi.ParseBytes(info.Size); err == nil {
+		repo.SetSize(size)
+	}
+
+	filePath := path.Join(info.Path, apis.FileRepositoryMeta)
+	exists, err := s.blob.Exists(ctx, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check repository metadata %s: %w", filePath, err)
+	}
+	if !exists {
+		klog.Infof("Repository metadata %s not found. Reconstructing repository %s/%s from the backend metadata.", filePath, info.Namespace, info.Name)
+		return repo, nil
+	}
+
+	data, err := s.blob.Get(ctx, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repository metadata %s: %w", filePath, err)
+	}
+	if err := yaml.Unmarshal(data, repo); err != nil {
+		return nil, fmt.Errorf("failed to decode repository metadata %s: %w", filePath, err)
+	}
+	cleanupObjectMeta(&repo.ObjectMeta)
+	return repo, nil
+}
+
+func (s *Syncer) syncObject(ctx context.Context, obj client.Object, report *Report) error {
+	ref := typedObjectRef(obj)
+
+	cur := obj.DeepCopyObject().(client.Object)
+	err := s.client.Get(ctx, client.ObjectKeyFromObject(obj), cur)
+	if err != nil && !kerr.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	if exists && s.opts.ConflictPolicy == ConflictPolicySkip {
+		report.Skipped = append(report.Skipped, ref)
+		return nil
+	}
+
+	if !s.opts.DryRun {
+		if err := s.apply(ctx, obj); err != nil {
+			return err
+		}
+	}
+
+	if exists {
+		report.Updated = append(report.Updated, ref)
+	} else {
+		report.Created = append(report.Created, ref)
+	}
+	return nil
+}
+
+func (s *Syncer) apply(ctx context.Context, obj client.Object) error {
+	switch desired := obj.(type) {
+	case *storageapi.Repository:
+		repo := &storageapi.Repository{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+		_, err := kmc.CreateOrPatch(ctx, s.client, repo, func(obj client.Object, createOp bool) client.Object {
+			in := obj.(*storageapi.Repository)
+			in.Labels = apis.UpsertLabels(in.Labels, desired.Labels)
+			in.Spec = desired.Spec
+			return in
+		})
+		if err != nil {
+			return err
+		}
+		_, err = kmc.PatchStatus(ctx, s.client, repo, func(obj client.Object) client.Object {
+			in := obj.(*storageapi.Repository)
+			in.Status = desired.Status
+			return in
+		})
+		return err
+	case *storageapi.Snapshot:
+		snap := &storageapi.Snapshot{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+		_, err := kmc.CreateOrPatch(ctx, s.client, snap, func(obj client.Object, createOp bool) client.Object {
+			in := obj.(*storageapi.Snapshot)
+			in.Labels = apis.UpsertLabels(in.Labels, desired.Labels)
+			in.Annotations = apis.UpsertLabels(in.Annotations, desired.Annotations)
+			in.Spec = desired.Spec
+			return in
+		})
+		if err != nil {
+			return err
+		}
+		_, err = kmc.PatchStatus(ctx, s.client, snap, func(obj client.Object) client.Object {
+			in := obj.(*storageapi.Snapshot)
+			in.Status = desired.Status
+			return in
+		})
+		return err
+	default:
+		return fmt.Errorf("unsupported object type %T", obj)
+	}
+}
+
+func setRepositoryStatusFromSnapshots(repo *storageapi.Repository, snapshots []storageapi.Snapshot) {
+	count := int32(len(snapshots))
+	repo.Status.SnapshotCount = &count
+
+	var sizeInByte uint64
+	for _, snap := range snapshots {
+		info := storageapi.SnapshotInfo{
+			Name:         snap.Name,
+			Phase:        snap.Status.Phase,
+			Session:      snap.Spec.Session,
+			Size:         snap.Status.Size,
+			SizeBytes:    snap.Status.SizeBytes,
+			SnapshotTime: snap.Status.SnapshotTime,
+		}
+		repo.Status.RecentSnapshots = append(repo.Status.RecentSnapshots, info)
+
+		if snap.Status.Phase != storageapi.SnapshotSucceeded || snap.Status.SnapshotTime == nil {
+			continue
+		}
+		if repo.Status.LastBackupTime == nil || repo.Status.LastBackupTime.Before(snap.Status.SnapshotTime) {
+			repo.Status.LastBackupTime = snap.Status.SnapshotTime
+		}
+		if size, ok := snap.GetSizeInBytes(); ok {
+			sizeInByte += size
+		}
+	}
+	if repo.Status.SizeBytes > 0 {
+		return
+	}
+	// the metadata written before SizeBytes was introduced only has the human-readable size
+	if size, err := storageapi.ParseBytes(repo.Status.Size); err == nil {
+		sizeInByte = size
+	}
+	if sizeInByte > 0 {
+		repo.SetSize(sizeInByte)
+	}
+}
+
+// cleanupObjectMeta removes the cluster specific metadata so that the object can be created in a new cluster.
+// The owner references are removed as the owners are unlikely to exist with the same UID.
+func cleanupObjectMeta(meta *metav1.ObjectMeta) {
+	meta.UID = ""
+	meta.ResourceVersion = ""
+	meta.Generation = 0
+	meta.CreationTimestamp = metav1.Time{}
+	meta.DeletionTimestamp = nil
+	meta.ManagedFields = nil
+	meta.OwnerReferences = nil
+	meta.Finalizers = nil
+}
+
+func typedObjectRef(obj client.Object) kmapi.TypedObjectReference {
+	ref := kmapi.TypedObjectReference{
+		APIGroup:  storageapi.GroupVersion.Group,
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}
+	switch obj.(type) {
+	case *storageapi.Repository:
+		ref.Kind = storageapi.ResourceKindRepository
+	case *storageapi.Snapshot:
+		ref.Kind = storageapi.ResourceKindSnapshot
+	}
+	return ref
+}
@@ -0,0 +1,141 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendsync
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+	configapi "kubestash.dev/apimachinery/apis/config/v1alpha1"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+	"kubestash.dev/apimachinery/pkg/blob"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+var repoInfo = storageapi.RepositoryInfo{Name: "s3-repo", Namespace: "demo", Path: "demo/mysql", Size: "1.500 MiB"}
+
+func TestSyncShouldCreateRepositoryAndSnapshotsFromBackend(t *testing.T) {
+	ctx := context.Background()
+	b := newSampleBlob(t)
+	c := newFakeClient(t)
+
+	s := NewSyncer(c, b, Options{StorageRef: kmapi.ObjectReference{Name: "s3-storage", Namespace: "demo"}})
+	report, err := s.Sync(ctx)
+	assert.NotNil(t, err, "the corrupted snapshot metadata should be reported")
+	assert.Len(t, report.Errors, 1)
+	assert.Len(t, report.Created, 2)
+
+	repo := &storageapi.Repository{}
+	assert.Nil(t, c.Get(ctx, client.ObjectKey{Name: repoInfo.Name, Namespace: repoInfo.Namespace}, repo))
+	assert.Equal(t, "s3-storage", repo.Spec.StorageRef.Name)
+	assert.Equal(t, repoInfo.Path, repo.Spec.Path)
+	assert.Equal(t, int32(1), *repo.Status.SnapshotCount)
+	assert.NotNil(t, repo.Status.LastBackupTime)
+	assert.Equal(t, "1.500 MiB", repo.Status.Size)
+	assert.Equal(t, int64(1536<<10), repo.Status.SizeBytes)
+
+	snap := &storageapi.Snapshot{}
+	assert.Nil(t, c.Get(ctx, client.ObjectKey{Name: "s3-repo-1", Namespace: "demo"}, snap))
+	assert.Equal(t, storageapi.SnapshotSucceeded, snap.Status.Phase)
+	assert.Empty(t, snap.OwnerReferences)
+}
+
+func TestSyncShouldSkipExistingObjectsByDefault(t *testing.T) {
+	ctx := context.Background()
+	b := newSampleBlob(t)
+	existing := &storageapi.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-repo-1", Namespace: "demo"},
+		Spec:       storageapi.SnapshotSpec{Repository: "s3-repo", Session: "manual"},
+	}
+	c := newFakeClient(t, existing)
+
+	report, _ := NewSyncer(c, b, Options{}).Sync(ctx)
+	assert.Len(t, report.Skipped, 1)
+
+	snap := &storageapi.Snapshot{}
+	assert.Nil(t, c.Get(ctx, client.ObjectKeyFromObject(existing), snap))
+	assert.Equal(t, "manual", snap.Spec.Session)
+
+	report, _ = NewSyncer(c, b, Options{ConflictPolicy: ConflictPolicyOverwrite}).Sync(ctx)
+	assert.Len(t, report.Updated, 2)
+
+	assert.Nil(t, c.Get(ctx, client.ObjectKeyFromObject(existing), snap))
+	assert.Equal(t, "full-backup", snap.Spec.Session)
+	assert.Equal(t, storageapi.SnapshotSucceeded, snap.Status.Phase)
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	assert.Nil(t, storageapi.AddToScheme(scheme))
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&storageapi.Repository{}, &storageapi.Snapshot{}).
+		Build()
+}
+
+func newSampleBlob(t *testing.T) *blob.Blob {
+	b, err := blob.NewBlob(context.Background(), nil, &storageapi.BackupStorage{
+		Spec: storageapi.BackupStorageSpec{
+			Storage: storageapi.Backend{
+				Provider: storageapi.ProviderLocal,
+				Local: &storageapi.LocalSpec{
+					MountPath: t.TempDir(),
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	uploadYAML(t, b, apis.FileBackendMeta, &configapi.BackendMeta{Repositories: []storageapi.RepositoryInfo{repoInfo}})
+	uploadYAML(t, b, path.Join(repoInfo.Path, apis.DirSnapshots, "s3-repo-1.yaml"), &storageapi.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "s3-repo-1",
+			Namespace: "demo",
+			UID:       "6b2f6a0e-55a4-4d7f-9c1c-3c1b1e0e5a11",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "storage.kubestash.com/v1alpha1", Kind: "Repository", Name: "s3-repo", UID: "stale"},
+			},
+		},
+		Spec: storageapi.SnapshotSpec{
+			Repository: "s3-repo",
+			Session:    "full-backup",
+		},
+		Status: storageapi.SnapshotStatus{
+			Phase:        storageapi.SnapshotSucceeded,
+			SnapshotTime: &metav1.Time{Time: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	})
+	assert.Nil(t, b.Upload(context.Background(), path.Join(repoInfo.Path, apis.DirSnapshots, "s3-repo-2.yaml"), []byte("{invalid"), ""))
+	return b
+}
+
+func uploadYAML(t *testing.T, b *blob.Blob, filePath string, obj any) {
+	data, err := yaml.Marshal(obj)
+	assert.Nil(t, err)
+	assert.Nil(t, b.Upload(context.Background(), filePath, data, ""))
+}
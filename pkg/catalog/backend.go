@@ -0,0 +1,116 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"kubestash.dev/apimachinery/apis"
+	configapi "kubestash.dev/apimachinery/apis/config/v1alpha1"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+	"kubestash.dev/apimachinery/pkg/blob"
+
+	"sigs.k8s.io/yaml"
+)
+
+// LoadBackendMeta reads the BackendMeta stored at the root of the backend.
+func LoadBackendMeta(ctx context.Context, b *blob.Blob) (*configapi.BackendMeta, error) {
+	data, err := b.Get(ctx, apis.FileBackendMeta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", apis.FileBackendMeta, err)
+	}
+	meta := &configapi.BackendMeta{}
+	if err := yaml.Unmarshal(data, meta); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", apis.FileBackendMeta, err)
+	}
+	return meta, nil
+}
+
+// LoadSnapshots reads the Snapshot metadata stored in the backend for the given Repository.
+// The metadata that can not be read or decoded are reported through the returned error,
+// while the Snapshots that were read successfully are still returned.
+func LoadSnapshots(ctx context.Context, b *blob.Blob, repo storageapi.RepositoryInfo) ([]storageapi.Snapshot, error) {
+	dir := path.Join(repo.Path, apis.DirSnapshots)
+	iter, closeIter, err := b.ListIterator(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots of repository %s/%s: %w", repo.Namespace, repo.Name, err)
+	}
+	defer closeIter()
+
+	var snapshots []storageapi.Snapshot
+	var errs []error
+	for {
+		obj, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return snapshots, errors.Join(append(errs, err)...)
+		}
+		if obj.IsDir || !strings.HasSuffix(obj.Key, ".yaml") {
+			continue
+		}
+
+		filePath := path.Join(dir, obj.Key)
+		data, err := b.Get(ctx, filePath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read snapshot metadata %s: %w", filePath, err))
+			continue
+		}
+		snap := storageapi.Snapshot{}
+		if err := yaml.Unmarshal(data, &snap); err != nil {
+			errs = append(errs, fmt.Errorf("failed to decode snapshot metadata %s: %w", filePath, err))
+			continue
+		}
+		if snap.Namespace == "" {
+			snap.Namespace = repo.Namespace
+		}
+		if snap.Spec.Repository == "" {
+			snap.Spec.Repository = repo.Name
+		}
+		snapshots = append(snapshots, snap)
+	}
+	return snapshots, errors.Join(errs...)
+}
+
+// NewFromBackend builds a Catalog from the BackendMeta and the Snapshot metadata stored in the backend.
+// It is useful to recover the Catalog when the Snapshot CRs are no longer available in the cluster.
+// Unreadable metadata are reported through the returned error along with the partially built Catalog.
+func NewFromBackend(ctx context.Context, b *blob.Blob) (*Catalog, error) {
+	meta, err := LoadBackendMeta(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	cat := New()
+	var errs []error
+	for _, repo := range meta.Repositories {
+		snapshots, err := LoadSnapshots(ctx, b, repo)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for i := range snapshots {
+			cat.Add(&snapshots[i])
+		}
+	}
+	return cat, errors.Join(errs...)
+}
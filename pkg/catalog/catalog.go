@@ -0,0 +1,230 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package catalog
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/types"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Entry holds the indexed information of a Snapshot
+type Entry struct {
+	Name               string
+	Namespace          string
+	Repository         string
+	Session            string
+	BackupSession      string
+	AppRef             kmapi.TypedObjectReference
+	Phase              storageapi.SnapshotPhase
+	VerificationStatus storageapi.VerificationStatus
+	SnapshotTime       time.Time
+	SizeBytes          int64
+}
+
+// Query specifies the conditions a Snapshot must satisfy to be returned from the Catalog.
+// Empty fields are ignored.
+type Query struct {
+	AppKind      string
+	AppName      string
+	AppNamespace string
+
+	Namespace     string
+	Repository    string
+	Session       string
+	BackupSession string
+
+	Phase              storageapi.SnapshotPhase
+	VerificationStatus storageapi.VerificationStatus
+
+	// Before selects the Snapshots taken at or before this time
+	Before *time.Time
+	// After selects the Snapshots taken at or after this time
+	After *time.Time
+
+	MinSizeBytes int64
+	MaxSizeBytes int64
+}
+
+// Catalog indexes Snapshots across Repositories so that they can be searched
+// by the application they belong to, time, session, phase, verification status and size.
+type Catalog struct {
+	mu      sync.RWMutex
+	entries map[types.NamespacedName]Entry
+}
+
+func New() *Catalog {
+	return &Catalog{
+		entries: make(map[types.NamespacedName]Entry),
+	}
+}
+
+// NewFromCluster builds a Catalog from the Snapshot CRs present in the cluster.
+func NewFromCluster(ctx context.Context, c client.Client, opts ...client.ListOption) (*Catalog, error) {
+	snapshots := &storageapi.SnapshotList{}
+	if err := c.List(ctx, snapshots, opts...); err != nil {
+		return nil, err
+	}
+
+	cat := New()
+	for i := range snapshots.Items {
+		cat.Add(&snapshots.Items[i])
+	}
+	return cat, nil
+}
+
+// Add inserts the Snapshot into the Catalog. An existing entry for the same Snapshot is replaced.
+func (c *Catalog) Add(snap *storageapi.Snapshot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[types.NamespacedName{Namespace: snap.Namespace, Name: snap.Name}] = newEntry(snap)
+}
+
+// Remove deletes the entry of the respective Snapshot from the Catalog.
+func (c *Catalog) Remove(namespace, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, types.NamespacedName{Namespace: namespace, Name: name})
+}
+
+// Len returns the number of Snapshots indexed in the Catalog.
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Search returns the entries matching the query, sorted from the newest to the oldest Snapshot.
+func (c *Catalog) Search(q Query) []Entry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []Entry
+	for _, e := range c.entries {
+		if q.matches(e) {
+			result = append(result, e)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].SnapshotTime.Equal(result[j].SnapshotTime) {
+			return result[i].Name > result[j].Name
+		}
+		return result[i].SnapshotTime.After(result[j].SnapshotTime)
+	})
+	return result
+}
+
+// Latest returns the newest entry matching the query.
+func (c *Catalog) Latest(q Query) (*Entry, bool) {
+	result := c.Search(q)
+	if len(result) == 0 {
+		return nil, false
+	}
+	return &result[0], true
+}
+
+func (q Query) matches(e Entry) bool {
+	if q.AppKind != "" && q.AppKind != e.AppRef.Kind {
+		return false
+	}
+	if q.AppName != "" && q.AppName != e.AppRef.Name {
+		return false
+	}
+	if q.AppNamespace != "" && q.AppNamespace != e.AppRef.Namespace {
+		return false
+	}
+	if q.Namespace != "" && q.Namespace != e.Namespace {
+		return false
+	}
+	if q.Repository != "" && q.Repository != e.Repository {
+		return false
+	}
+	if q.Session != "" && q.Session != e.Session {
+		return false
+	}
+	if q.BackupSession != "" && q.BackupSession != e.BackupSession {
+		return false
+	}
+	if q.Phase != "" && q.Phase != e.Phase {
+		return false
+	}
+	if q.VerificationStatus != "" && q.VerificationStatus != e.VerificationStatus {
+		return false
+	}
+	if q.Before != nil && e.SnapshotTime.After(*q.Before) {
+		return false
+	}
+	if q.After != nil && e.SnapshotTime.Before(*q.After) {
+		return false
+	}
+	if q.MinSizeBytes > 0 && e.SizeBytes < q.MinSizeBytes {
+		return false
+	}
+	if q.MaxSizeBytes > 0 && e.SizeBytes > q.MaxSizeBytes {
+		return false
+	}
+	return true
+}
+
+func newEntry(snap *storageapi.Snapshot) Entry {
+	e := Entry{
+		Name:               snap.Name,
+		Namespace:          snap.Namespace,
+		Repository:         snap.Spec.Repository,
+		Session:            snap.Spec.Session,
+		BackupSession:      snap.Spec.BackupSession,
+		AppRef:             getAppRef(snap),
+		Phase:              snap.Status.Phase,
+		VerificationStatus: snap.Status.VerificationStatus,
+		SnapshotTime:       snap.CreationTimestamp.Time,
+		SizeBytes:          snap.Status.SizeBytes,
+	}
+	if snap.Status.SnapshotTime != nil {
+		e.SnapshotTime = snap.Status.SnapshotTime.Time
+	}
+	if e.SizeBytes == 0 {
+		if size, ok := snap.GetSizeInBytes(); ok {
+			e.SizeBytes = int64(size)
+		}
+	}
+	return e
+}
+
+// getAppRef returns the application reference of the Snapshot. The labels set by KubeStash
+// take precedence over the spec so that the Snapshots can be indexed the same way they are selected.
+func getAppRef(snap *storageapi.Snapshot) kmapi.TypedObjectReference {
+	ref := snap.Spec.AppRef
+	if v, ok := snap.Labels[apis.KubeStashAppRefKind]; ok {
+		ref.Kind = v
+	}
+	if v, ok := snap.Labels[apis.KubeStashAppRefName]; ok {
+		ref.Name = v
+	}
+	if v, ok := snap.Labels[apis.KubeStashAppRefNamespace]; ok {
+		ref.Namespace = v
+	}
+	return ref
+}
@@ -0,0 +1,133 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package catalog
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+	configapi "kubestash.dev/apimachinery/apis/config/v1alpha1"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+	"kubestash.dev/apimachinery/pkg/blob"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var baseTime = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func TestLatestVerifiedSnapshotBeforeTime(t *testing.T) {
+	cat := New()
+	cat.Add(sampleSnapshot("gcs-repo-1", "gcs-repo", "mysql", baseTime, storageapi.SnapshotVerified))
+	cat.Add(sampleSnapshot("s3-repo-2", "s3-repo", "mysql", baseTime.Add(time.Hour), storageapi.SnapshotVerified))
+	cat.Add(sampleSnapshot("s3-repo-3", "s3-repo", "mysql", baseTime.Add(2*time.Hour), storageapi.SnapshotNotVerified))
+	cat.Add(sampleSnapshot("s3-repo-4", "s3-repo", "mysql", baseTime.Add(3*time.Hour), storageapi.SnapshotVerified))
+	cat.Add(sampleSnapshot("s3-repo-5", "s3-repo", "postgres", baseTime.Add(2*time.Hour), storageapi.SnapshotVerified))
+
+	before := baseTime.Add(150 * time.Minute)
+	entry, found := cat.Latest(Query{
+		AppKind:            "MySQL",
+		AppName:            "mysql",
+		AppNamespace:       "demo",
+		VerificationStatus: storageapi.SnapshotVerified,
+		Before:             &before,
+	})
+	assert.True(t, found)
+	assert.Equal(t, "s3-repo-2", entry.Name)
+
+	entries := cat.Search(Query{AppName: "mysql", Repository: "s3-repo"})
+	assert.Equal(t, []string{"s3-repo-4", "s3-repo-3", "s3-repo-2"}, entryNames(entries))
+
+	cat.Remove("demo", "s3-repo-2")
+	_, found = cat.Latest(Query{AppName: "mysql", Repository: "s3-repo", Before: &before, VerificationStatus: storageapi.SnapshotVerified})
+	assert.False(t, found)
+}
+
+func TestNewFromBackend(t *testing.T) {
+	ctx := context.Background()
+	b, err := blob.NewBlob(ctx, nil, &storageapi.BackupStorage{
+		Spec: storageapi.BackupStorageSpec{
+			Storage: storageapi.Backend{
+				Provider: storageapi.ProviderLocal,
+				Local: &storageapi.LocalSpec{
+					MountPath: t.TempDir(),
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	repo := storageapi.RepositoryInfo{Name: "s3-repo", Namespace: "demo", Path: "demo/mysql"}
+	uploadYAML(t, b, apis.FileBackendMeta, &configapi.BackendMeta{Repositories: []storageapi.RepositoryInfo{repo}})
+	uploadYAML(t, b, path.Join(repo.Path, apis.DirSnapshots, "s3-repo-1.yaml"),
+		sampleSnapshot("s3-repo-1", "s3-repo", "mysql", baseTime, storageapi.SnapshotVerified))
+	assert.Nil(t, b.Upload(ctx, path.Join(repo.Path, apis.DirSnapshots, "s3-repo-2.yaml"), []byte("{invalid"), ""))
+
+	cat, err := NewFromBackend(ctx, b)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, cat.Len())
+
+	entry, found := cat.Latest(Query{AppName: "mysql"})
+	assert.True(t, found)
+	assert.Equal(t, "s3-repo-1", entry.Name)
+	assert.Equal(t, "demo", entry.Namespace)
+}
+
+func sampleSnapshot(name, repo, app string, snapshotTime time.Time, verification storageapi.VerificationStatus) *storageapi.Snapshot {
+	kind := "MySQL"
+	if app == "postgres" {
+		kind = "Postgres"
+	}
+	return &storageapi.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "demo",
+			Labels: map[string]string{
+				apis.KubeStashAppRefKind:      kind,
+				apis.KubeStashAppRefName:      app,
+				apis.KubeStashAppRefNamespace: "demo",
+			},
+		},
+		Spec: storageapi.SnapshotSpec{
+			Repository: repo,
+			Session:    "full-backup",
+		},
+		Status: storageapi.SnapshotStatus{
+			Phase:              storageapi.SnapshotSucceeded,
+			VerificationStatus: verification,
+			SnapshotTime:       &metav1.Time{Time: snapshotTime},
+		},
+	}
+}
+
+func uploadYAML(t *testing.T, b *blob.Blob, filePath string, obj any) {
+	data, err := yaml.Marshal(obj)
+	assert.Nil(t, err)
+	assert.Nil(t, b.Upload(context.Background(), filePath, data, ""))
+}
+
+func entryNames(entries []Entry) []string {
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	return names
+}
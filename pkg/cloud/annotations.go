@@ -375,7 +375,8 @@ func findLatestSuccessfulBackupSession(ctx context.Context, kbClient client.Clie
 		if session.Status.Phase != v1alpha1.BackupSessionFailed {
 			notFailed += 1
 		}
-		if session.Status.Phase == v1alpha1.BackupSessionSucceeded {
+		if session.Status.Phase == v1alpha1.BackupSessionSucceeded ||
+			session.Status.Phase == v1alpha1.BackupSessionPartiallySucceeded {
 			return session, nil
 		}
 	}
@@ -0,0 +1,128 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concurrency
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	configapi "kubestash.dev/apimachinery/apis/config/v1alpha1"
+	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
+
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+// Request represents a BackupSession along with the resources it uses while running
+type Request struct {
+	Session *coreapi.BackupSession
+	// BackupStorages specifies the BackupStorages the session writes to
+	BackupStorages []kmapi.ObjectReference
+	// Nodes specifies the nodes the backup executors of the session run on
+	Nodes []string
+}
+
+// Limiter limits the number of BackupSessions running at the same time according to the KubeStashConfig
+type Limiter struct {
+	limits configapi.ConcurrencyLimits
+}
+
+func NewLimiter(limits *configapi.ConcurrencyLimits) *Limiter {
+	l := &Limiter{}
+	if limits != nil {
+		l.limits = *limits
+	}
+	return l
+}
+
+// Admit decides which of the waiting sessions can start running without exceeding the limits.
+// The waiting sessions are admitted in the order they were created. A session that can not be admitted
+// does not block the following sessions that use different resources.
+// The admitted sessions are removed from the queue, while the rest are marked as Queued with their position.
+func (l *Limiter) Admit(running, waiting []Request) (admitted, queued []Request) {
+	usage := newUsage()
+	for _, r := range running {
+		usage.add(r)
+	}
+
+	// sort a copy, so that the order of the caller's slice is kept
+	ordered := slices.Clone(waiting)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Session.CreationTimestamp.Before(&ordered[j].Session.CreationTimestamp)
+	})
+
+	for _, r := range ordered {
+		reason, msg := l.exceededLimit(usage, r)
+		if reason == "" {
+			usage.add(r)
+			r.Session.RemoveBackupQueuedCondition()
+			admitted = append(admitted, r)
+			continue
+		}
+		queued = append(queued, r)
+		r.Session.SetBackupQueuedConditionToTrue(int32(len(queued)), reason, msg)
+	}
+	return admitted, queued
+}
+
+func (l *Limiter) exceededLimit(u *usage, r Request) (string, string) {
+	for _, storage := range r.BackupStorages {
+		if exceeds(l.limits.MaxSessionsPerBackupStorage, u.storages[storage]) {
+			return coreapi.ReasonBackupStorageConcurrencyLimitReached,
+				fmt.Sprintf("Maximum %d sessions are already running against BackupStorage %s/%s.", *l.limits.MaxSessionsPerBackupStorage, storage.Namespace, storage.Name)
+		}
+	}
+	if exceeds(l.limits.MaxSessionsPerNamespace, u.namespaces[r.Session.Namespace]) {
+		return coreapi.ReasonNamespaceConcurrencyLimitReached,
+			fmt.Sprintf("Maximum %d sessions are already running in namespace %s.", *l.limits.MaxSessionsPerNamespace, r.Session.Namespace)
+	}
+	for _, node := range r.Nodes {
+		if exceeds(l.limits.MaxSessionsPerNode, u.nodes[node]) {
+			return coreapi.ReasonNodeConcurrencyLimitReached,
+				fmt.Sprintf("Maximum %d sessions are already running on node %s.", *l.limits.MaxSessionsPerNode, node)
+		}
+	}
+	return "", ""
+}
+
+func exceeds(limit *int32, running int32) bool {
+	return limit != nil && running >= *limit
+}
+
+type usage struct {
+	storages   map[kmapi.ObjectReference]int32
+	namespaces map[string]int32
+	nodes      map[string]int32
+}
+
+func newUsage() *usage {
+	return &usage{
+		storages:   make(map[kmapi.ObjectReference]int32),
+		namespaces: make(map[string]int32),
+		nodes:      make(map[string]int32),
+	}
+}
+
+func (u *usage) add(r Request) {
+	for _, storage := range r.BackupStorages {
+		u.storages[storage]++
+	}
+	u.namespaces[r.Session.Namespace]++
+	for _, node := range r.Nodes {
+		u.nodes[node]++
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	configapi "kubestash.dev/apimachinery/apis/config/v1alpha1"
+	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+var (
+	s3    = kmapi.ObjectReference{Name: "s3", Namespace: "stash"}
+	gcs   = kmapi.ObjectReference{Name: "gcs", Namespace: "stash"}
+	start = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestLimiterAdmit(t *testing.T) {
+	tests := []struct {
+		name             string
+		limits           *configapi.ConcurrencyLimits
+		running          []Request
+		waiting          []Request
+		expectedAdmitted []string
+		expectedQueued   []string
+		expectedReason   string
+	}{
+		{
+			name:             "All sessions should be admitted if no limit is configured",
+			running:          []Request{newRequest("running", "demo", 0, s3)},
+			waiting:          []Request{newRequest("b", "demo", 2, s3), newRequest("a", "demo", 1, s3)},
+			expectedAdmitted: []string{"a", "b"},
+		},
+		{
+			name:             "Sessions should be queued in creation order if the BackupStorage limit is reached",
+			limits:           &configapi.ConcurrencyLimits{MaxSessionsPerBackupStorage: ptr.To(int32(2))},
+			running:          []Request{newRequest("running", "demo", 0, s3)},
+			waiting:          []Request{newRequest("c", "demo", 3, s3), newRequest("a", "demo", 1, s3), newRequest("b", "demo", 2, s3)},
+			expectedAdmitted: []string{"a"},
+			expectedQueued:   []string{"b", "c"},
+			expectedReason:   coreapi.ReasonBackupStorageConcurrencyLimitReached,
+		},
+		{
+			name:             "Queued sessions should not block the sessions using other BackupStorages",
+			limits:           &configapi.ConcurrencyLimits{MaxSessionsPerBackupStorage: ptr.To(int32(1))},
+			running:          []Request{newRequest("running", "demo", 0, s3)},
+			waiting:          []Request{newRequest("a", "demo", 1, s3), newRequest("b", "demo", 2, gcs)},
+			expectedAdmitted: []string{"b"},
+			expectedQueued:   []string{"a"},
+			expectedReason:   coreapi.ReasonBackupStorageConcurrencyLimitReached,
+		},
+		{
+			name:             "Sessions should be queued if the namespace limit is reached",
+			limits:           &configapi.ConcurrencyLimits{MaxSessionsPerNamespace: ptr.To(int32(1))},
+			waiting:          []Request{newRequest("a", "demo", 1, s3), newRequest("b", "demo", 2, gcs), newRequest("c", "prod", 3, gcs)},
+			expectedAdmitted: []string{"a", "c"},
+			expectedQueued:   []string{"b"},
+			expectedReason:   coreapi.ReasonNamespaceConcurrencyLimitReached,
+		},
+		{
+			name:             "Sessions should be queued if the node limit is reached",
+			limits:           &configapi.ConcurrencyLimits{MaxSessionsPerNode: ptr.To(int32(1))},
+			running:          []Request{withNodes(newRequest("running", "demo", 0), "node-1")},
+			waiting:          []Request{withNodes(newRequest("a", "prod", 1), "node-1"), withNodes(newRequest("b", "prod", 2), "node-2")},
+			expectedAdmitted: []string{"b"},
+			expectedQueued:   []string{"a"},
+			expectedReason:   coreapi.ReasonNodeConcurrencyLimitReached,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			waiting := sessionNames(test.waiting)
+			admitted, queued := NewLimiter(test.limits).Admit(test.running, test.waiting)
+			assert.Equal(t, waiting, sessionNames(test.waiting), "the order of the waiting sessions should be kept")
+			assert.Equal(t, test.expectedAdmitted, sessionNames(admitted))
+			assert.Equal(t, test.expectedQueued, sessionNames(queued))
+
+			for _, r := range admitted {
+				assert.False(t, r.Session.IsQueued())
+				assert.Nil(t, r.Session.Status.Queue)
+			}
+			for i, r := range queued {
+				assert.Equal(t, coreapi.BackupSessionQueued, r.Session.CalculatePhase())
+				assert.Equal(t, int32(i+1), r.Session.Status.Queue.Position)
+				assert.Equal(t, test.expectedReason, r.Session.Status.Queue.Reason)
+			}
+		})
+	}
+}
+
+func TestLimiterShouldKeepEnqueueTime(t *testing.T) {
+	limiter := NewLimiter(&configapi.ConcurrencyLimits{MaxSessionsPerNamespace: ptr.To(int32(1))})
+	running := []Request{newRequest("running", "demo", 0)}
+	waiting := []Request{newRequest("a", "demo", 1)}
+
+	_, queued := limiter.Admit(running, waiting)
+	enqueueTime := queued[0].Session.Status.Queue.EnqueueTime
+
+	_, queued = limiter.Admit(running, waiting)
+	assert.Equal(t, enqueueTime, queued[0].Session.Status.Queue.EnqueueTime)
+
+	admitted, _ := limiter.Admit(nil, waiting)
+	assert.Equal(t, []string{"a"}, sessionNames(admitted))
+	assert.NotEqual(t, coreapi.BackupSessionQueued, admitted[0].Session.CalculatePhase())
+}
+
+func newRequest(name, namespace string, createdAfter time.Duration, storages ...kmapi.ObjectReference) Request {
+	return Request{
+		Session: &coreapi.BackupSession{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(start.Add(createdAfter * time.Minute)),
+			},
+		},
+		BackupStorages: storages,
+	}
+}
+
+func withNodes(r Request, nodes ...string) Request {
+	r.Nodes = nodes
+	return r
+}
+
+func sessionNames(requests []Request) []string {
+	var names []string
+	for _, r := range requests {
+		names = append(names, r.Session.Name)
+	}
+	return names
+}
@@ -0,0 +1,216 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httphook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
+	"kubestash.dev/apimachinery/pkg/retry"
+
+	"gomodules.xyz/envsubst"
+	core "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultRetryInterval = 5 * time.Second
+	defaultTimeout       = 30 * time.Second
+)
+
+// Executor executes the hooks of HTTP type executor
+type Executor struct {
+	// KubeClient is used to read the Secrets referred by the headers
+	KubeClient client.Client
+	// HTTPClient is used to send the requests. http.DefaultClient is used if it is not set.
+	// Each request is bounded by the timeout of the executor spec regardless of the client.
+	HTTPClient *http.Client
+}
+
+// Result represents the outcome of the last request sent by the executor
+type Result struct {
+	StatusCode int
+	// Body holds the tail of the response body, limited to apis.MaxHookStdoutSize bytes
+	Body     string
+	Attempts int32
+}
+
+// retryableError represents a failure that may succeed on a later attempt, i.e. a connection failure,
+// a timed out request or a 5xx or 429 response status code.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// Execute resolves the request using the given inputs, sends it and retries on a connection failure,
+// a timeout or a 5xx or 429 response status code. The Secrets referred by the headers are read from the given namespace.
+func (e *Executor) Execute(ctx context.Context, namespace string, spec *coreapi.HTTPHookExecutorSpec, inputs map[string]string) (*Result, error) {
+	req, err := resolveRequest(spec, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the request. Reason: %w", err)
+	}
+
+	headers, err := e.resolveHeaders(ctx, namespace, req.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := retry.NewRetryConfig(func(rc *retry.RetryConfig) {
+		rc.MaxRetries = uint64(req.MaxRetry)
+		rc.Delay = defaultRetryInterval
+		if req.RetryInterval != nil {
+			rc.Delay = req.RetryInterval.Duration
+		}
+		rc.ShouldRetry = func(err error, _ string) bool {
+			var re *retryableError
+			return errors.As(err, &re)
+		}
+	})
+
+	result := &Result{}
+	_, err = cfg.RunWithRetry(ctx, func() (any, error) {
+		result.Attempts++
+		return nil, e.send(ctx, req, headers, result)
+	})
+	return result, err
+}
+
+// resolveRequest substitutes the inputs in the URL, the header values and the body of the request separately.
+// The inputs are JSON escaped in the body so that they can not break or inject fields into a JSON body.
+func resolveRequest(spec *coreapi.HTTPHookExecutorSpec, inputs map[string]string) (*coreapi.HTTPHookExecutorSpec, error) {
+	req := spec.DeepCopy()
+	var err error
+	if req.URL, err = envsubst.EvalMap(req.URL, inputs); err != nil {
+		return nil, err
+	}
+	for i := range req.Headers {
+		if req.Headers[i].Value, err = envsubst.EvalMap(req.Headers[i].Value, inputs); err != nil {
+			return nil, err
+		}
+	}
+	if req.Body, err = envsubst.EvalMap(req.Body, escapeJSON(inputs)); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func escapeJSON(inputs map[string]string) map[string]string {
+	escaped := make(map[string]string, len(inputs))
+	for k, v := range inputs {
+		data, _ := json.Marshal(v)
+		escaped[k] = string(data[1 : len(data)-1])
+	}
+	return escaped
+}
+
+func (e *Executor) send(ctx context.Context, spec *coreapi.HTTPHookExecutorSpec, headers http.Header, result *Result) error {
+	timeout := defaultTimeout
+	if spec.Timeout != nil {
+		timeout = spec.Timeout.Duration
+	}
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var body io.Reader
+	if spec.Body != "" {
+		body = strings.NewReader(spec.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, string(spec.GetMethod()), spec.URL, body)
+	if err != nil {
+		return err
+	}
+	req.Header = headers.Clone()
+
+	httpClient := e.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return toAttemptError(parent, err, timeout)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return toAttemptError(parent, err, timeout)
+	}
+	result.StatusCode = resp.StatusCode
+	result.Body = apis.TruncateTail(string(data), apis.MaxHookStdoutSize)
+
+	if !spec.IsExpectedStatusCode(resp.StatusCode) {
+		err := fmt.Errorf("unexpected response status code %d", resp.StatusCode)
+		if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+			return &retryableError{err: err}
+		}
+		return err
+	}
+	return nil
+}
+
+// toAttemptError marks the given transport error as retryable unless the parent context is done.
+// The timeout of a single attempt is reported without the context error so that it is not treated
+// as a cancellation of the whole execution.
+func toAttemptError(parent context.Context, err error, timeout time.Duration) error {
+	if parent.Err() != nil {
+		return err
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &retryableError{err: fmt.Errorf("request timed out after %s", timeout)}
+	}
+	return &retryableError{err: err}
+}
+
+func (e *Executor) resolveHeaders(ctx context.Context, namespace string, headers []coreapi.HTTPHeader) (http.Header, error) {
+	resolved := make(http.Header)
+	for _, header := range headers {
+		if header.SecretKeyRef == nil {
+			resolved.Add(header.Name, header.Value)
+			continue
+		}
+		secret := &core.Secret{}
+		if err := e.KubeClient.Get(ctx, client.ObjectKey{Name: header.SecretKeyRef.Name, Namespace: namespace}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get Secret %s/%s for header %q. Reason: %w", namespace, header.SecretKeyRef.Name, header.Name, err)
+		}
+		value, ok := secret.Data[header.SecretKeyRef.Key]
+		if !ok && ptr.Deref(header.SecretKeyRef.Optional, false) {
+			continue
+		}
+		if !ok {
+			return nil, fmt.Errorf("key %q is not found in Secret %s/%s for header %q", header.SecretKeyRef.Key, namespace, header.SecretKeyRef.Name, header.Name)
+		}
+		resolved.Add(header.Name, strings.TrimSpace(string(value)))
+	}
+	return resolved, nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httphook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExecute(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/freeze/mysql", r.URL.Path)
+		assert.Equal(t, "Bearer s3cr3t", r.Header.Get("Authorization"))
+		assert.Equal(t, `{"session":"mysql-backup"}`, string(body))
+		if requests < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("frozen"))
+	}))
+	defer server.Close()
+
+	secret := &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hook-token", Namespace: "demo"},
+		Data:       map[string][]byte{"token": []byte("Bearer s3cr3t\n")},
+	}
+	e := &Executor{KubeClient: fake.NewClientBuilder().WithObjects(secret).Build()}
+
+	spec := &coreapi.HTTPHookExecutorSpec{
+		Method: coreapi.HTTPMethodPut,
+		URL:    server.URL + "/freeze/${DATABASE}",
+		Headers: []coreapi.HTTPHeader{
+			{
+				Name: "Authorization",
+				SecretKeyRef: &core.SecretKeySelector{
+					LocalObjectReference: core.LocalObjectReference{Name: "hook-token"},
+					Key:                  "token",
+				},
+			},
+		},
+		Body:                `{"session":"${SESSION}"}`,
+		ExpectedStatusCodes: []int32{http.StatusAccepted},
+		MaxRetry:            2,
+		RetryInterval:       &metav1.Duration{Duration: 1},
+	}
+	inputs := map[string]string{"DATABASE": "mysql", "SESSION": "mysql-backup"}
+
+	result, err := e.Execute(context.Background(), "demo", spec, inputs)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), result.Attempts)
+	assert.Equal(t, http.StatusAccepted, result.StatusCode)
+	assert.Equal(t, "frozen", result.Body)
+	assert.Equal(t, server.URL+"/freeze/${DATABASE}", spec.URL, "the spec should not be modified")
+
+	spec.MaxRetry = 0
+	spec.ExpectedStatusCodes = []int32{http.StatusOK}
+	result, err = e.Execute(context.Background(), "demo", spec, inputs)
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), result.Attempts)
+	assert.Equal(t, http.StatusAccepted, result.StatusCode)
+}
+
+func TestExecuteTimesOutHungRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	e := &Executor{KubeClient: fake.NewClientBuilder().Build()}
+	spec := &coreapi.HTTPHookExecutorSpec{
+		URL:     server.URL,
+		Timeout: &metav1.Duration{Duration: 50 * time.Millisecond},
+	}
+
+	start := time.Now()
+	result, err := e.Execute(context.Background(), "demo", spec, nil)
+	assert.ErrorContains(t, err, "timed out")
+	assert.Equal(t, int32(1), result.Attempts)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestExecuteRetriesTimedOutRequest(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			select {
+			case <-time.After(5 * time.Second):
+			case <-r.Context().Done():
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	e := &Executor{KubeClient: fake.NewClientBuilder().Build()}
+	spec := &coreapi.HTTPHookExecutorSpec{
+		URL:           server.URL,
+		Timeout:       &metav1.Duration{Duration: 50 * time.Millisecond},
+		MaxRetry:      2,
+		RetryInterval: &metav1.Duration{Duration: 1},
+	}
+
+	result, err := e.Execute(context.Background(), "demo", spec, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), result.Attempts)
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+}
+
+func TestExecuteRetriesOnlyRetryableStatusCodes(t *testing.T) {
+	tests := []struct {
+		name             string
+		statusCode       int
+		expectedAttempts int32
+	}{
+		{
+			name:             "Request should be retried if the server fails",
+			statusCode:       http.StatusBadGateway,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "Request should be retried if the server is rate limiting",
+			statusCode:       http.StatusTooManyRequests,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "Request should not be retried if it is rejected by the server",
+			statusCode:       http.StatusBadRequest,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.statusCode)
+			}))
+			defer server.Close()
+
+			e := &Executor{KubeClient: fake.NewClientBuilder().Build()}
+			spec := &coreapi.HTTPHookExecutorSpec{
+				URL:           server.URL,
+				MaxRetry:      2,
+				RetryInterval: &metav1.Duration{Duration: 1},
+			}
+
+			result, err := e.Execute(context.Background(), "demo", spec, nil)
+			assert.NotNil(t, err)
+			assert.Equal(t, test.expectedAttempts, result.Attempts)
+		})
+	}
+}
+
+func TestExecuteEscapesInputsInBody(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	e := &Executor{KubeClient: fake.NewClientBuilder().Build()}
+	spec := &coreapi.HTTPHookExecutorSpec{
+		URL:  server.URL,
+		Body: `{"message":"${MESSAGE}"}`,
+	}
+
+	_, err := e.Execute(context.Background(), "demo", spec, map[string]string{"MESSAGE": "a\"b\\c\n\",\"admin\":\"true"})
+	assert.Nil(t, err)
+
+	var decoded map[string]string
+	assert.Nil(t, json.Unmarshal([]byte(body), &decoded))
+	assert.Equal(t, map[string]string{"message": "a\"b\\c\n\",\"admin\":\"true"}, decoded)
+}
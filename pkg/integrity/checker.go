@@ -0,0 +1,181 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrity
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+
+	shell "gomodules.xyz/go-sh"
+	"gomodules.xyz/restic"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+// Checker checks the integrity of the restic repositories configured in the restic wrapper
+type Checker struct {
+	sh      *shell.Session
+	wrapper *restic.ResticWrapper
+}
+
+func NewChecker(opts *restic.SetupOptions) (*Checker, error) {
+	sh := shell.NewSession()
+	wrapper, err := restic.NewResticWrapperFromShell(opts, sh)
+	if err != nil {
+		return nil, err
+	}
+	return &Checker{
+		sh:      sh,
+		wrapper: wrapper,
+	}, nil
+}
+
+// Check checks the integrity of the given repository according to the policy and returns the result.
+// The failure to run the check is recorded in the result rather than returned as an error so that
+// it can be reported in the Repository status.
+func (c *Checker) Check(repository string, policy storageapi.IntegrityCheckPolicy) storageapi.IntegrityCheckResult {
+	result := storageapi.IntegrityCheckResult{
+		StartTime:          &metav1.Time{Time: time.Now()},
+		Mode:               policy.GetMode(),
+		ReadDataPercentage: policy.GetReadDataPercentage(),
+	}
+
+	var report CheckReport
+	switch result.Mode {
+	case storageapi.IntegrityCheckModeReadData:
+		report = c.checkData(repository, result.ReadDataPercentage, &result)
+	default:
+		report = c.checkMetadata(repository, &result)
+	}
+	if policy.RepairDamagedPacks && len(report.DamagedPacks) > 0 {
+		c.repairPacks(repository, report.DamagedPacks, &result)
+	}
+
+	result.CompletionTime = &metav1.Time{Time: time.Now()}
+	duration := result.CompletionTime.Sub(result.StartTime.Time).Round(time.Second)
+	result.Duration = duration.String()
+	result.DurationSeconds = int64(duration.Seconds())
+	return result
+}
+
+func (c *Checker) checkMetadata(repository string, result *storageapi.IntegrityCheckResult) CheckReport {
+	klog.Infof("Checking integrity of the metadata of repository %s", repository)
+	return c.runCheck(repository, nil, result)
+}
+
+func (c *Checker) checkData(repository string, percentage int32, result *storageapi.IntegrityCheckResult) CheckReport {
+	klog.Infof("Checking integrity of repository %s by reading %d%% of the data", repository, percentage)
+	return c.runCheck(repository, []any{fmt.Sprintf("--read-data-subset=%d%%", percentage)}, result)
+}
+
+// runCheck runs "restic check" with the given extra arguments and records the errors it reports in the result
+func (c *Checker) runCheck(repository string, extraArgs []any, result *storageapi.IntegrityCheckResult) CheckReport {
+	out, err := c.runRestic(repository, append([]any{"check", "--no-lock", "--no-cache"}, extraArgs...))
+
+	report := ParseCheckOutput(out)
+	result.ErrorsFound = report.ErrorsFound
+	if err != nil && report.ErrorsFound == 0 {
+		// the check could not be performed, so the integrity is unknown
+		result.Error = fmt.Sprintf("%s: %s", err, strings.TrimSpace(string(out)))
+		return report
+	}
+	result.Integrity = ptr.To(report.NoErrors && report.ErrorsFound == 0)
+	if !*result.Integrity {
+		result.Error = strings.Join(report.Errors, "\n")
+	}
+	return report
+}
+
+// repairPacks runs "restic repair packs" for the given damaged packs and records the number of repaired packs in the result
+func (c *Checker) repairPacks(repository string, packs []string, result *storageapi.IntegrityCheckResult) {
+	klog.Infof("Repairing %d damaged packs of repository %s", len(packs), repository)
+	args := []any{"repair", "packs"}
+	for _, pack := range packs {
+		args = append(args, pack)
+	}
+	out, err := c.runRestic(repository, args)
+	if err != nil {
+		result.Error = strings.TrimSpace(fmt.Sprintf("%s\nfailed to repair the damaged packs. Reason: %s: %s", result.Error, err, strings.TrimSpace(string(out))))
+		return
+	}
+	result.RepairedPacks = int64(len(packs))
+}
+
+func (c *Checker) runRestic(repository string, args []any) ([]byte, error) {
+	b := c.wrapper.Config.GetBackend(repository)
+	if b == nil {
+		return nil, fmt.Errorf("no backend found for repository %q", repository)
+	}
+
+	if b.CaCertFile != "" {
+		args = append(args, "--cacert", b.CaCertFile)
+	}
+	if b.StorageConfig != nil && b.InsecureTLS {
+		args = append(args, "--insecure-tls")
+	}
+	args = append(args, b.Envs)
+
+	if c.wrapper.Config.Timeout != nil {
+		c.sh.SetTimeout(c.wrapper.Config.Timeout.Duration)
+	}
+	return c.sh.Command(restic.ResticCMD, args...).CombinedOutput()
+}
+
+// CheckReport holds the information extracted from the output of "restic check" command
+type CheckReport struct {
+	// NoErrors is true if restic reported that no errors were found
+	NoErrors bool
+	// ErrorsFound is the number of errors reported by restic
+	ErrorsFound int64
+	// Errors holds the error messages reported by restic
+	Errors []string
+	// DamagedPacks holds the IDs of the damaged packs that restic suggested to repair
+	DamagedPacks []string
+}
+
+// ParseCheckOutput extracts the errors reported in the output of "restic check" command.
+// A fatal error without any reported error line is counted as a single error.
+func ParseCheckOutput(out []byte) CheckReport {
+	var report CheckReport
+	var fatal string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "no errors were found":
+			report.NoErrors = true
+		case strings.HasPrefix(line, "error"), strings.HasPrefix(line, "Pack ID does not match"):
+			report.ErrorsFound++
+			report.Errors = append(report.Errors, line)
+		case strings.HasPrefix(line, "Fatal: repository contains errors"):
+			fatal = line
+		case strings.HasPrefix(line, "restic repair packs "):
+			report.DamagedPacks = append(report.DamagedPacks, strings.Fields(strings.TrimPrefix(line, "restic repair packs "))...)
+		}
+	}
+	if fatal != "" && report.ErrorsFound == 0 {
+		report.ErrorsFound = 1
+		report.Errors = append(report.Errors, fatal)
+	}
+	return report
+}
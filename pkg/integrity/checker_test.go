@@ -0,0 +1,88 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCheckOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected CheckReport
+	}{
+		{
+			name: "healthy repository",
+			output: `using temporary cache in /tmp/restic-check-cache-1
+check snapshots, trees and blobs
+read 10.0% of data packs
+[0:01] 100.00%  3 / 3 packs
+no errors were found`,
+			expected: CheckReport{NoErrors: true},
+		},
+		{
+			name: "damaged packs",
+			output: `check snapshots, trees and blobs
+read 10.0% of data packs
+Pack ID does not match, want 2818331a, got 4a2ba2a6
+error for tree 4bdff87c:
+  tree 4bdff87c: file "foo" blob 0: not found in index
+Fatal: repository contains errors`,
+			expected: CheckReport{
+				ErrorsFound: 2,
+				Errors: []string{
+					"Pack ID does not match, want 2818331a, got 4a2ba2a6",
+					"error for tree 4bdff87c:",
+				},
+			},
+		},
+		{
+			name: "fatal error without error lines",
+			output: `check snapshots, trees and blobs
+Fatal: repository contains errors`,
+			expected: CheckReport{
+				ErrorsFound: 1,
+				Errors:      []string{"Fatal: repository contains errors"},
+			},
+		},
+		{
+			name: "damaged packs to repair",
+			output: `check snapshots, trees and blobs
+error: pack 2818331a: not referenced in any index
+The repository contains damaged pack files. These damaged files must be removed to repair the repository.
+
+restic repair packs 2818331a 4a2ba2a6
+restic repair snapshots --forget
+
+Fatal: repository contains errors`,
+			expected: CheckReport{
+				ErrorsFound:  1,
+				Errors:       []string{"error: pack 2818331a: not referenced in any index"},
+				DamagedPacks: []string{"2818331a", "4a2ba2a6"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ParseCheckOutput([]byte(test.output)))
+		})
+	}
+}
@@ -0,0 +1,398 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"kubestash.dev/apimachinery/apis"
+	addonapi "kubestash.dev/apimachinery/apis/addons/v1alpha1"
+	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+
+	"gomodules.xyz/restic"
+	authorization "k8s.io/api/authorization/v1"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Run runs all the preflight checks of a dry-run restore and records their results as the conditions
+// of the RestoreSession. No data is read from or written to the target.
+// The restic options are used to check the encryption secret. The check is recorded as skipped if they are nil.
+func Run(ctx context.Context, c client.Client, rs *coreapi.RestoreSession, snap *storageapi.Snapshot, opts *restic.SetupOptions) {
+	rs.SetPreflightCheckCondition(coreapi.TypePreflightSnapshotReady, CheckSnapshot(snap))
+	if opts != nil {
+		rs.SetPreflightCheckCondition(coreapi.TypePreflightEncryptionSecretValid, CheckEncryptionSecret(opts))
+	} else {
+		rs.SetPreflightCheckSkipped(coreapi.TypePreflightEncryptionSecretValid, "no restic options have been provided")
+	}
+	rs.SetPreflightCheckCondition(coreapi.TypePreflightTargetAvailable, CheckTarget(ctx, c, rs, snap))
+	rs.SetPreflightCheckCondition(coreapi.TypePreflightComponentsAvailable, CheckComponents(rs, snap))
+	rs.SetPreflightCheckCondition(coreapi.TypePreflightAddonTasksAvailable, CheckAddonTasks(ctx, c, rs.Spec.Addon))
+	rs.SetPreflightCheckCondition(coreapi.TypePreflightStorageCapacitySufficient, CheckCapacity(ctx, c, rs, snap))
+	rs.SetPreflightCheckCondition(coreapi.TypePreflightPermissionsGranted, CheckPermissions(ctx, c, rs, snap))
+}
+
+// CheckSnapshot checks whether the given Snapshot exists and has succeeded
+func CheckSnapshot(snap *storageapi.Snapshot) error {
+	if snap == nil {
+		return fmt.Errorf("snapshot not found")
+	}
+	if snap.Status.Phase != storageapi.SnapshotSucceeded {
+		return fmt.Errorf("snapshot %s/%s is in %q phase", snap.Namespace, snap.Name, snap.Status.Phase)
+	}
+	return nil
+}
+
+// CheckEncryptionSecret checks whether the configured encryption secrets can decrypt the restic repositories
+func CheckEncryptionSecret(opts *restic.SetupOptions) error {
+	w, err := restic.NewResticWrapper(opts)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, b := range opts.Backends {
+		if _, err := w.ListSnapshots(b.Repository, nil); err != nil {
+			errs = append(errs, fmt.Errorf("failed to open repository %q: %w", b.Repository, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CheckTarget checks whether the restore target exists. A missing target is accepted
+// if it will be created by restoring its manifests.
+func CheckTarget(ctx context.Context, c client.Client, rs *coreapi.RestoreSession, snap *storageapi.Snapshot) error {
+	if snap == nil {
+		return fmt.Errorf("unable to resolve the target without the snapshot")
+	}
+
+	_, err := getTarget(ctx, c, rs, snap)
+	if err == nil {
+		return nil
+	}
+	if kerr.IsNotFound(err) && restoresManifests(rs) {
+		return nil
+	}
+	return err
+}
+
+func getTarget(ctx context.Context, c client.Client, rs *coreapi.RestoreSession, snap *storageapi.Snapshot) (*unstructured.Unstructured, error) {
+	mapping, err := getTargetMapping(c, rs, snap)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := rs.GetTargetObjectRef(snap)
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(mapping.GroupVersionKind)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func getTargetMapping(c client.Client, rs *coreapi.RestoreSession, snap *storageapi.Snapshot) (*meta.RESTMapping, error) {
+	gk := schema.GroupKind{Group: snap.Spec.AppRef.APIGroup, Kind: snap.Spec.AppRef.Kind}
+	if rs.Spec.Target != nil {
+		gk = schema.GroupKind{Group: rs.Spec.Target.APIGroup, Kind: rs.Spec.Target.Kind}
+	}
+	mapping, err := c.RESTMapper().RESTMapping(gk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the resource of kind %q: %w", gk.String(), err)
+	}
+	return mapping, nil
+}
+
+func restoresManifests(rs *coreapi.RestoreSession) bool {
+	if rs.Spec.Addon == nil {
+		return false
+	}
+	return slices.ContainsFunc(rs.Spec.Addon.Tasks, func(t coreapi.TaskReference) bool {
+		return t.Name == apis.ManifestRestore
+	})
+}
+
+// CheckComponents checks whether the components requested in the DataSource are present in the Snapshot
+func CheckComponents(rs *coreapi.RestoreSession, snap *storageapi.Snapshot) error {
+	if snap == nil {
+		return fmt.Errorf("unable to resolve the components without the snapshot")
+	}
+	if missing := rs.GetMissingComponents(snap); len(missing) > 0 {
+		return fmt.Errorf("components %s not found in snapshot %s/%s", strings.Join(missing, ", "), snap.Namespace, snap.Name)
+	}
+	return nil
+}
+
+// CheckAddonTasks checks whether the Addon exists and provides the requested restore tasks
+func CheckAddonTasks(ctx context.Context, c client.Client, info *coreapi.AddonInfo) error {
+	if info == nil {
+		return fmt.Errorf("addon is not specified")
+	}
+
+	addon := &addonapi.Addon{}
+	if err := c.Get(ctx, client.ObjectKey{Name: info.Name}, addon); err != nil {
+		return fmt.Errorf("failed to get addon %q: %w", info.Name, err)
+	}
+
+	var missing []string
+	for _, task := range info.Tasks {
+		if !slices.ContainsFunc(addon.Spec.RestoreTasks, func(t addonapi.Task) bool { return t.Name == task.Name }) {
+			missing = append(missing, task.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("restore tasks %s not found in addon %q", strings.Join(missing, ", "), info.Name)
+	}
+	return nil
+}
+
+// RequiredCapacity returns the number of bytes required to restore the requested components of the Snapshot.
+// It is the size of the backed up data rather than the size in the repository, which is deduplicated and compressed.
+// The components whose data size is not known are not counted, so the result is a lower bound.
+func RequiredCapacity(rs *coreapi.RestoreSession, snap *storageapi.Snapshot) int64 {
+	var components []string
+	if rs.Spec.DataSource != nil {
+		components = rs.Spec.DataSource.Components
+	}
+
+	var size int64
+	for name, comp := range snap.Status.Components {
+		if len(components) > 0 && !slices.Contains(components, name) {
+			continue
+		}
+		if dataSize, ok := comp.GetDataSizeInBytes(); ok {
+			size += int64(dataSize)
+		}
+	}
+	return size
+}
+
+// CheckCapacity checks whether the PVCs the data will be restored into are large enough to hold the
+// requested components. The PVCs are the target itself if it is a PVC, and the PVCs of the task volumes.
+// The current usage of the PVCs is not exposed by the API, so the restored data is assumed to replace their content.
+func CheckCapacity(ctx context.Context, c client.Client, rs *coreapi.RestoreSession, snap *storageapi.Snapshot) error {
+	if snap == nil {
+		return fmt.Errorf("unable to calculate the required capacity without the snapshot")
+	}
+
+	pvcs, err := getTargetPVCs(ctx, c, rs, snap)
+	if err != nil {
+		return err
+	}
+	if len(pvcs) == 0 {
+		return nil
+	}
+
+	var capacity int64
+	for _, pvc := range pvcs {
+		if size, ok := pvc.Status.Capacity[core.ResourceStorage]; ok {
+			capacity += size.Value()
+		} else if size, ok := pvc.Spec.Resources.Requests[core.ResourceStorage]; ok {
+			capacity += size.Value()
+		}
+	}
+
+	if required := RequiredCapacity(rs, snap); required > capacity {
+		return fmt.Errorf("restoring requires %d bytes but the target PVCs have a capacity of %d bytes", required, capacity)
+	}
+	return nil
+}
+
+func getTargetPVCs(ctx context.Context, c client.Client, rs *coreapi.RestoreSession, snap *storageapi.Snapshot) ([]core.PersistentVolumeClaim, error) {
+	ref := rs.GetTargetObjectRef(snap)
+
+	var pvcs []core.PersistentVolumeClaim
+	for _, name := range getTargetPVCNames(rs) {
+		pvc := core.PersistentVolumeClaim{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: name}, &pvc); err != nil {
+			return nil, fmt.Errorf("failed to get PVC %s/%s: %w", ref.Namespace, name, err)
+		}
+		pvcs = append(pvcs, pvc)
+	}
+	return pvcs, nil
+}
+
+func getTargetPVCNames(rs *coreapi.RestoreSession) []string {
+	var names []string
+	if target := rs.Spec.Target; target != nil && target.APIGroup == "" && target.Kind == apis.KindPersistentVolumeClaim {
+		names = append(names, target.Name)
+	}
+	if rs.Spec.Addon != nil {
+		for _, task := range rs.Spec.Addon.Tasks {
+			if task.TargetVolumes == nil {
+				continue
+			}
+			for _, vol := range task.TargetVolumes.Volumes {
+				if vol.PersistentVolumeClaim != nil && !slices.Contains(names, vol.PersistentVolumeClaim.ClaimName) {
+					names = append(names, vol.PersistentVolumeClaim.ClaimName)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// CheckPermissions checks whether the ServiceAccount of the restore job is allowed to perform the operations
+// required for restoring. The check is skipped if no ServiceAccount is specified in the job template,
+// as the RBAC resources of the restore job are then managed by KubeStash.
+func CheckPermissions(ctx context.Context, c client.Client, rs *coreapi.RestoreSession, snap *storageapi.Snapshot) error {
+	if rs.Spec.Addon == nil || rs.Spec.Addon.JobTemplate == nil || rs.Spec.Addon.JobTemplate.Spec.ServiceAccountName == "" {
+		return nil
+	}
+	user := fmt.Sprintf("system:serviceaccount:%s:%s", rs.Namespace, rs.Spec.Addon.JobTemplate.Spec.ServiceAccountName)
+
+	attrs, err := RequiredPermissions(ctx, c, rs, snap)
+	if err != nil {
+		return err
+	}
+
+	var denied []string
+	for _, attr := range attrs {
+		sar := &authorization.SubjectAccessReview{
+			Spec: authorization.SubjectAccessReviewSpec{
+				User:               user,
+				ResourceAttributes: &attr,
+			},
+		}
+		if err := c.Create(ctx, sar); err != nil {
+			return fmt.Errorf("failed to review the permissions of %q: %w", user, err)
+		}
+		if !sar.Status.Allowed {
+			denied = append(denied, formatAttributes(attr))
+		}
+	}
+	if len(denied) > 0 {
+		return fmt.Errorf("%q is not allowed to %s", user, strings.Join(denied, ", "))
+	}
+	return nil
+}
+
+// RequiredPermissions returns the operations the restore job needs to perform on the cluster resources.
+// Besides reading the Snapshot and the encryption secret, the job writes into the target and its PVCs.
+// The existing objects are updated, while the missing ones are created.
+func RequiredPermissions(ctx context.Context, c client.Client, rs *coreapi.RestoreSession, snap *storageapi.Snapshot) ([]authorization.ResourceAttributes, error) {
+	attrs := []authorization.ResourceAttributes{
+		{
+			Namespace: rs.GetDataSourceNamespace(),
+			Verb:      "get",
+			Group:     storageapi.GroupVersion.Group,
+			Resource:  storageapi.ResourcePluralSnapshot,
+		},
+		{
+			Namespace:   rs.Namespace,
+			Verb:        "patch",
+			Group:       coreapi.GroupVersion.Group,
+			Resource:    coreapi.ResourcePluralRestoreSession,
+			Subresource: "status",
+		},
+	}
+
+	if ds := rs.Spec.DataSource; ds != nil && ds.EncryptionSecret != nil {
+		es := ds.EncryptionSecret
+		attrs = append(attrs, authorization.ResourceAttributes{
+			Namespace: getNamespace(es, rs.Namespace),
+			Verb:      "get",
+			Resource:  "secrets",
+			Name:      es.Name,
+		})
+	}
+
+	if snap != nil {
+		mapping, err := getTargetMapping(c, rs, snap)
+		if err != nil {
+			return nil, err
+		}
+		ref := rs.GetTargetObjectRef(snap)
+		target := authorization.ResourceAttributes{
+			Namespace: ref.Namespace,
+			Group:     mapping.Resource.Group,
+			Resource:  mapping.Resource.Resource,
+			Name:      ref.Name,
+		}
+		_, err = getTarget(ctx, c, rs, snap)
+		if err != nil && !kerr.IsNotFound(err) {
+			return nil, err
+		}
+		attrs = append(attrs, writeAttributes(target, err == nil)...)
+
+		for _, name := range getTargetPVCNames(rs) {
+			if name == ref.Name && mapping.Resource.Group == "" && mapping.Resource.Resource == "persistentvolumeclaims" {
+				continue
+			}
+			err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: name}, &core.PersistentVolumeClaim{})
+			if err != nil && !kerr.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get PVC %s/%s: %w", ref.Namespace, name, err)
+			}
+			pvc := authorization.ResourceAttributes{
+				Namespace: ref.Namespace,
+				Resource:  "persistentvolumeclaims",
+				Name:      name,
+			}
+			attrs = append(attrs, writeAttributes(pvc, err == nil)...)
+		}
+	}
+	return attrs, nil
+}
+
+// writeAttributes returns the operations required to update the given object if it exists, or to create it otherwise
+func writeAttributes(obj authorization.ResourceAttributes, exists bool) []authorization.ResourceAttributes {
+	if !exists {
+		// the name is not known to the authorizer when an object is being created
+		obj.Verb, obj.Name = "create", ""
+		return []authorization.ResourceAttributes{obj}
+	}
+
+	var attrs []authorization.ResourceAttributes
+	for _, verb := range []string{"get", "patch"} {
+		attr := obj
+		attr.Verb = verb
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+func getNamespace(ref *kmapi.ObjectReference, defaultNamespace string) string {
+	if ref.Namespace == "" {
+		return defaultNamespace
+	}
+	return ref.Namespace
+}
+
+func formatAttributes(attr authorization.ResourceAttributes) string {
+	resource := attr.Resource
+	if attr.Group != "" {
+		resource = resource + "." + attr.Group
+	}
+	if attr.Subresource != "" {
+		resource = resource + "/" + attr.Subresource
+	}
+	target := attr.Namespace
+	if attr.Name != "" {
+		target = target + "/" + attr.Name
+	}
+	return fmt.Sprintf("%s %s in %s", attr.Verb, resource, target)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"testing"
+
+	addonapi "kubestash.dev/apimachinery/apis/addons/v1alpha1"
+	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	authorization "k8s.io/api/authorization/v1"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	cutil "kmodules.xyz/client-go/conditions"
+	ofst "kmodules.xyz/offshoot-api/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestCheckSnapshot(t *testing.T) {
+	assert.Error(t, CheckSnapshot(nil))
+	assert.Error(t, CheckSnapshot(sampleSnapshot(storageapi.SnapshotFailed)))
+	assert.NoError(t, CheckSnapshot(sampleSnapshot(storageapi.SnapshotSucceeded)))
+}
+
+func TestCheckAddonTasks(t *testing.T) {
+	c := newFakeClient(t, nil, &addonapi.Addon{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-addon"},
+		Spec: addonapi.AddonSpec{
+			RestoreTasks: []addonapi.Task{{Name: "logical-backup-restore"}},
+		},
+	})
+
+	tests := []struct {
+		name      string
+		addon     *coreapi.AddonInfo
+		expectErr bool
+	}{
+		{
+			name:      "Check should fail if the addon is not specified",
+			expectErr: true,
+		},
+		{
+			name:      "Check should fail if the addon does not exist",
+			addon:     &coreapi.AddonInfo{Name: "mysql-addon"},
+			expectErr: true,
+		},
+		{
+			name:      "Check should fail if a task does not exist in the addon",
+			addon:     &coreapi.AddonInfo{Name: "pvc-addon", Tasks: []coreapi.TaskReference{{Name: "logical-backup-restore"}, {Name: "volume-snapshot-restore"}}},
+			expectErr: true,
+		},
+		{
+			name:  "Check should pass if all the tasks exist in the addon",
+			addon: &coreapi.AddonInfo{Name: "pvc-addon", Tasks: []coreapi.TaskReference{{Name: "logical-backup-restore"}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckAddonTasks(context.Background(), c, test.addon)
+			assert.Equal(t, test.expectErr, err != nil, err)
+		})
+	}
+}
+
+func TestCheckTargetAndCapacity(t *testing.T) {
+	pvc := &core.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "demo"},
+		Status: core.PersistentVolumeClaimStatus{
+			Capacity: core.ResourceList{core.ResourceStorage: resource.MustParse("1Ki")},
+		},
+	}
+	c := newFakeClient(t, nil, pvc)
+	snap := sampleSnapshot(storageapi.SnapshotSucceeded)
+
+	rs := sampleRestoreSession("data")
+	assert.NoError(t, CheckTarget(context.Background(), c, rs, snap))
+	assert.NoError(t, CheckCapacity(context.Background(), c, rs, snap))
+
+	rs.Spec.DataSource.Components = nil
+	assert.Equal(t, int64(1536), RequiredCapacity(rs, snap))
+	assert.Error(t, CheckCapacity(context.Background(), c, rs, snap))
+
+	rs = sampleRestoreSession("missing")
+	assert.True(t, kerr.IsNotFound(CheckTarget(context.Background(), c, rs, snap)))
+	rs.Spec.Addon.Tasks = append(rs.Spec.Addon.Tasks, coreapi.TaskReference{Name: "manifest-restore"})
+	assert.NoError(t, CheckTarget(context.Background(), c, rs, snap))
+}
+
+func TestCheckPermissions(t *testing.T) {
+	var reviewed []authorization.ResourceAttributes
+	c := newFakeClient(t, &interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			sar, ok := obj.(*authorization.SubjectAccessReview)
+			if !ok {
+				return c.Create(ctx, obj, opts...)
+			}
+			assert.Equal(t, "system:serviceaccount:demo:restore-job", sar.Spec.User)
+			reviewed = append(reviewed, *sar.Spec.ResourceAttributes)
+			sar.Status.Allowed = sar.Spec.ResourceAttributes.Resource != "secrets"
+			return nil
+		},
+	})
+	snap := sampleSnapshot(storageapi.SnapshotSucceeded)
+
+	rs := sampleRestoreSession("data")
+	assert.NoError(t, CheckPermissions(context.Background(), c, rs, snap))
+	assert.Empty(t, reviewed)
+
+	rs.Spec.Addon.JobTemplate = &ofst.PodTemplateSpec{Spec: ofst.PodSpec{ServiceAccountName: "restore-job"}}
+	assert.NoError(t, CheckPermissions(context.Background(), c, rs, snap))
+	assert.Len(t, reviewed, 3)
+	assert.Contains(t, reviewed, authorization.ResourceAttributes{Namespace: "demo", Verb: "create", Resource: "persistentvolumeclaims"})
+
+	reviewed = nil
+	assert.Nil(t, c.Create(context.Background(), &core.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "demo"}}))
+	assert.NoError(t, CheckPermissions(context.Background(), c, rs, snap))
+	assert.Len(t, reviewed, 4)
+	assert.Contains(t, reviewed, authorization.ResourceAttributes{Namespace: "demo", Verb: "patch", Resource: "persistentvolumeclaims", Name: "data"})
+	assert.NotContains(t, reviewed, authorization.ResourceAttributes{Namespace: "demo", Verb: "create", Resource: "persistentvolumeclaims"})
+
+	rs.Spec.DataSource.EncryptionSecret = &kmapi.ObjectReference{Name: "encrypt-secret"}
+	err := CheckPermissions(context.Background(), c, rs, snap)
+	assert.ErrorContains(t, err, "get secrets in demo/encrypt-secret")
+}
+
+func TestRunSetsPreflightConditions(t *testing.T) {
+	c := newFakeClient(t, nil, &addonapi.Addon{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-addon"},
+		Spec: addonapi.AddonSpec{
+			RestoreTasks: []addonapi.Task{{Name: "logical-backup-restore"}},
+		},
+	})
+
+	rs := sampleRestoreSession("missing")
+	rs.Spec.DryRun = true
+	Run(context.Background(), c, rs, sampleSnapshot(storageapi.SnapshotSucceeded), nil)
+
+	assert.Len(t, rs.Status.Conditions, len(coreapi.PreflightChecks()))
+	assert.Equal(t, coreapi.RestoreFailed, rs.CalculatePhase())
+}
+
+func TestRunReportsSkippedChecksWithoutResticOptions(t *testing.T) {
+	c := newFakeClient(t, nil,
+		&addonapi.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc-addon"},
+			Spec: addonapi.AddonSpec{
+				RestoreTasks: []addonapi.Task{{Name: "logical-backup-restore"}},
+			},
+		},
+		&core.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "demo"},
+			Status: core.PersistentVolumeClaimStatus{
+				Capacity: core.ResourceList{core.ResourceStorage: resource.MustParse("1Ki")},
+			},
+		},
+	)
+
+	rs := sampleRestoreSession("data")
+	rs.Spec.DryRun = true
+	Run(context.Background(), c, rs, sampleSnapshot(storageapi.SnapshotSucceeded), nil)
+
+	_, cond := cutil.GetCondition(rs.Status.Conditions, coreapi.TypePreflightEncryptionSecretValid)
+	assert.NotNil(t, cond)
+	assert.Equal(t, coreapi.ReasonPreflightCheckSkipped, cond.Reason)
+	assert.Equal(t, metav1.ConditionUnknown, cond.Status)
+	assert.Equal(t, coreapi.RestorePreflightSkipped, rs.CalculatePhase())
+}
+
+func newFakeClient(t *testing.T, funcs *interceptor.Funcs, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	assert.Nil(t, clientgoscheme.AddToScheme(scheme))
+	assert.Nil(t, addonapi.AddToScheme(scheme))
+
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{core.SchemeGroupVersion})
+	mapper.Add(core.SchemeGroupVersion.WithKind("PersistentVolumeClaim"), meta.RESTScopeNamespace)
+
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(objs...)
+	if funcs != nil {
+		builder = builder.WithInterceptorFuncs(*funcs)
+	}
+	return builder.Build()
+}
+
+func sampleSnapshot(phase storageapi.SnapshotPhase) *storageapi.Snapshot {
+	return &storageapi.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-backup-1700000000", Namespace: "demo"},
+		Spec: storageapi.SnapshotSpec{
+			AppRef: kmapi.TypedObjectReference{Kind: "PersistentVolumeClaim", Name: "data", Namespace: "demo"},
+		},
+		Status: storageapi.SnapshotStatus{
+			Phase: phase,
+			Components: map[string]storageapi.Component{
+				"dump":     {SizeBytes: 128, ResticStats: []storageapi.ResticStats{{Progress: &storageapi.BackupProgress{TotalBytes: 512}}}},
+				"manifest": {SizeBytes: 256, ResticStats: []storageapi.ResticStats{{Summary: &storageapi.ResticSummary{Size: "1.000 KiB"}}}},
+			},
+		},
+	}
+}
+
+func sampleRestoreSession(target string) *coreapi.RestoreSession {
+	return &coreapi.RestoreSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-restore", Namespace: "demo"},
+		Spec: coreapi.RestoreSessionSpec{
+			Target: &kmapi.TypedObjectReference{Kind: "PersistentVolumeClaim", Name: target, Namespace: "demo"},
+			DataSource: &coreapi.RestoreDataSource{
+				Repository: "gcs-repo",
+				Snapshot:   "pvc-backup-1700000000",
+				Components: []string{"dump"},
+			},
+			Addon: &coreapi.AddonInfo{
+				Name:  "pvc-addon",
+				Tasks: []coreapi.TaskReference{{Name: "logical-backup-restore"}},
+			},
+		},
+	}
+}
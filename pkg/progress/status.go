@@ -32,18 +32,21 @@ import (
 
 func (pg *Progress) setBackupProgress(repoName string, status *restic.ResticStatus) error {
 	progress := &storageapi.BackupProgress{
-		SecondsElapsed: status.SecondsElapsed,
-		TotalFiles:     int64(status.TotalFiles),
-		BackupDone:     units.HumanSize(float64(status.BytesDone)),
+		SecondsElapsed:  status.SecondsElapsed,
+		TotalFiles:      int64(status.TotalFiles),
+		BackupDone:      units.HumanSize(float64(status.BytesDone)),
+		BackupDoneBytes: int64(status.BytesDone),
 	}
 	if status.TotalBytes > 0 {
 		progress.Total = units.HumanSize(float64(status.TotalBytes))
+		progress.TotalBytes = int64(status.TotalBytes)
 	}
 	if status.PercentDone*100 > 0 {
 		progress.PercentDone = fmt.Sprintf("%.2f%%", status.PercentDone*100)
 	}
 	if status.SecondsElapsed > 0 {
 		progress.Speed = units.HumanSize(float64(status.BytesDone)/float64(status.SecondsElapsed)) + "/s"
+		progress.SpeedBytesPerSecond = int64(status.BytesDone) / status.SecondsElapsed
 	}
 
 	for idx := range pg.snapshots {
@@ -0,0 +1,375 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
+
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	meta_util "kmodules.xyz/client-go/meta"
+	"kubedb.dev/apimachinery/apis/kubedb"
+	dbapi "kubedb.dev/apimachinery/apis/kubedb/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamespaceName returns the name of the sandbox namespace of the given BackupVerificationSession.
+// The name is stable, so that the provisioning can be resumed after a crash.
+func NamespaceName(spec *coreapi.SandboxSpec, session *coreapi.BackupVerificationSession) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(session.Namespace + "/" + session.Name))
+	return meta_util.NameWithSuffix(spec.GetNamespacePrefix(), fmt.Sprintf("%08x", h.Sum32()))
+}
+
+// Provision creates the sandbox namespace for the given BackupVerificationSession along with its
+// NetworkPolicy and ResourceQuota, and clones the target into it. The existing resources are reused.
+func Provision(ctx context.Context, c client.Client, spec *coreapi.SandboxSpec, session *coreapi.BackupVerificationSession, target *unstructured.Unstructured, now time.Time) (*coreapi.SandboxStatus, error) {
+	ns := NewNamespace(spec, session, now)
+	if err := createIfNotExists(ctx, c, ns); err != nil {
+		return nil, fmt.Errorf("failed to create sandbox namespace %s: %w", ns.Name, err)
+	}
+
+	// the namespace may have been created by an earlier attempt, so the times are read back from it
+	if err := c.Get(ctx, client.ObjectKeyFromObject(ns), ns); err != nil {
+		return nil, fmt.Errorf("failed to get sandbox namespace %s: %w", ns.Name, err)
+	}
+	creationTime, err := getTime(ns, apis.KeySandboxCreationTime)
+	if err != nil {
+		return nil, err
+	}
+	expiryTime, err := getTime(ns, apis.KeySandboxExpiryTime)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &coreapi.SandboxStatus{
+		Namespace:    ns.Name,
+		Phase:        coreapi.SandboxProvisioning,
+		CreationTime: &metav1.Time{Time: creationTime},
+		ExpiryTime:   &metav1.Time{Time: expiryTime},
+	}
+
+	if !spec.DisableNetworkIsolation {
+		apiServer, err := getAPIServerEndpoints(ctx, c)
+		if err != nil {
+			return status, err
+		}
+		isolation := NewNetworkPolicy(ns.Name, spec.GetOperatorNamespaces(), apiServer)
+		for _, policy := range []*networking.NetworkPolicy{isolation, NewRestoreEgressPolicy(ns.Name)} {
+			if err := createIfNotExists(ctx, c, policy); err != nil {
+				return status, fmt.Errorf("failed to isolate sandbox namespace %s: %w", ns.Name, err)
+			}
+		}
+	}
+	if len(spec.ResourceQuota) > 0 {
+		if err := createIfNotExists(ctx, c, NewResourceQuota(ns.Name, spec.ResourceQuota)); err != nil {
+			return status, fmt.Errorf("failed to limit resources of sandbox namespace %s: %w", ns.Name, err)
+		}
+	}
+
+	clone, err := CloneTarget(target, ns.Name, spec)
+	if err != nil {
+		return status, err
+	}
+	if err := createIfNotExists(ctx, c, clone); err != nil {
+		return status, fmt.Errorf("failed to clone %s %s/%s into sandbox namespace %s: %w", target.GetKind(), target.GetNamespace(), target.GetName(), ns.Name, err)
+	}
+
+	status.Phase = coreapi.SandboxReady
+	return status, nil
+}
+
+// getAPIServerEndpoints returns the endpoints of the Kubernetes API server. It returns nil if they are not available.
+func getAPIServerEndpoints(ctx context.Context, c client.Client) (*core.Endpoints, error) {
+	endpoints := &core.Endpoints{}
+	err := c.Get(ctx, client.ObjectKey{Name: "kubernetes", Namespace: metav1.NamespaceDefault}, endpoints)
+	if kerr.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the endpoints of the Kubernetes API server: %w", err)
+	}
+	return endpoints, nil
+}
+
+func getTime(ns *core.Namespace, key string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, ns.Annotations[key])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s annotation in sandbox namespace %s: %w", key, ns.Name, err)
+	}
+	return t, nil
+}
+
+func createIfNotExists(ctx context.Context, c client.Client, obj client.Object) error {
+	if err := c.Create(ctx, obj); err != nil && !kerr.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// NewNamespace returns the sandbox namespace of the given BackupVerificationSession.
+// The expiry time is recorded in the namespace, so that it can be cleaned up even if the session is lost.
+func NewNamespace(spec *coreapi.SandboxSpec, session *coreapi.BackupVerificationSession, now time.Time) *core.Namespace {
+	return &core.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: NamespaceName(spec, session),
+			Labels: map[string]string{
+				meta_util.ManagedByLabelKey:    apis.KubeStashKey,
+				apis.KubeStashSandbox:          "true",
+				apis.KubeStashInvokerName:      session.Name,
+				apis.KubeStashInvokerNamespace: session.Namespace,
+			},
+			Annotations: map[string]string{
+				apis.KeySandboxCreationTime: now.UTC().Format(time.RFC3339),
+				apis.KeySandboxExpiryTime:   now.Add(spec.GetTTL()).UTC().Format(time.RFC3339),
+			},
+		},
+	}
+}
+
+// NewNetworkPolicy returns a NetworkPolicy that isolates the sandbox namespace. It only allows the traffic
+// within the sandbox namespace itself and from the operator namespaces, along with the DNS lookups and the
+// requests to the Kubernetes API server. The API server is allowed by its port if its endpoints are not known.
+func NewNetworkPolicy(namespace string, operatorNamespaces []string, apiServer *core.Endpoints) *networking.NetworkPolicy {
+	dnsPort := intstr.FromInt32(53)
+	ingressPeers := []networking.NetworkPolicyPeer{
+		{PodSelector: &metav1.LabelSelector{}},
+	}
+	for _, ns := range operatorNamespaces {
+		ingressPeers = append(ingressPeers, networking.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{core.LabelMetadataName: ns},
+			},
+		})
+	}
+	return &networking.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apis.SandboxNetworkPolicyName,
+			Namespace: namespace,
+		},
+		Spec: networking.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress, networking.PolicyTypeEgress},
+			Ingress: []networking.NetworkPolicyIngressRule{
+				{
+					From: ingressPeers,
+				},
+			},
+			Egress: []networking.NetworkPolicyEgressRule{
+				{
+					To: []networking.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{}},
+					},
+				},
+				{
+					Ports: []networking.NetworkPolicyPort{
+						{Protocol: ptr.To(core.ProtocolUDP), Port: &dnsPort},
+						{Protocol: ptr.To(core.ProtocolTCP), Port: &dnsPort},
+					},
+				},
+				newAPIServerEgressRule(apiServer),
+			},
+		},
+	}
+}
+
+func newAPIServerEgressRule(apiServer *core.Endpoints) networking.NetworkPolicyEgressRule {
+	if apiServer == nil {
+		return networking.NetworkPolicyEgressRule{
+			Ports: []networking.NetworkPolicyPort{
+				{Protocol: ptr.To(core.ProtocolTCP), Port: ptr.To(intstr.FromInt32(443))},
+				{Protocol: ptr.To(core.ProtocolTCP), Port: ptr.To(intstr.FromInt32(6443))},
+			},
+		}
+	}
+
+	var rule networking.NetworkPolicyEgressRule
+	for _, subset := range apiServer.Subsets {
+		for _, addr := range subset.Addresses {
+			cidr := addr.IP + "/32"
+			if strings.Contains(addr.IP, ":") {
+				cidr = addr.IP + "/128"
+			}
+			rule.To = append(rule.To, networking.NetworkPolicyPeer{IPBlock: &networking.IPBlock{CIDR: cidr}})
+		}
+		for _, port := range subset.Ports {
+			rule.Ports = append(rule.Ports, networking.NetworkPolicyPort{Protocol: ptr.To(core.ProtocolTCP), Port: ptr.To(intstr.FromInt32(port.Port))})
+		}
+	}
+	return rule
+}
+
+// NewRestoreEgressPolicy returns a NetworkPolicy that allows the pods managed by KubeStash, i.e. the restore job,
+// to reach the backend storage from the sandbox namespace. The cloned workload remains isolated.
+func NewRestoreEgressPolicy(namespace string) *networking.NetworkPolicy {
+	return &networking.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apis.SandboxRestoreEgressPolicyName,
+			Namespace: namespace,
+		},
+		Spec: networking.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{meta_util.ManagedByLabelKey: apis.KubeStashKey},
+			},
+			PolicyTypes: []networking.PolicyType{networking.PolicyTypeEgress},
+			Egress:      []networking.NetworkPolicyEgressRule{{}},
+		},
+	}
+}
+
+// NewResourceQuota returns a ResourceQuota that caps the resources used in the sandbox namespace
+func NewResourceQuota(namespace string, hard core.ResourceList) *core.ResourceQuota {
+	return &core.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apis.SandboxResourceQuotaName,
+			Namespace: namespace,
+		},
+		Spec: core.ResourceQuotaSpec{
+			Hard: hard.DeepCopy(),
+		},
+	}
+}
+
+// CloneTarget returns a copy of the target in the sandbox namespace. The number of replicas is
+// scaled down and the storage class is overridden according to the sandbox configuration.
+// A KubeDB database is cloned in a running state and with "WipeOut" deletion policy, so that it
+// does not block the teardown of the sandbox.
+func CloneTarget(target *unstructured.Unstructured, namespace string, spec *coreapi.SandboxSpec) (*unstructured.Unstructured, error) {
+	targetSpec, found, err := unstructured.NestedMap(target.Object, "spec")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec of %s %s/%s: %w", target.GetKind(), target.GetNamespace(), target.GetName(), err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%s %s/%s has no spec to clone", target.GetKind(), target.GetNamespace(), target.GetName())
+	}
+
+	clone := &unstructured.Unstructured{Object: map[string]any{"spec": targetSpec}}
+	clone.SetAPIVersion(target.GetAPIVersion())
+	clone.SetKind(target.GetKind())
+	clone.SetName(target.GetName())
+	clone.SetNamespace(namespace)
+	clone.SetLabels(target.GetLabels())
+
+	if _, found := targetSpec["replicas"]; found {
+		if err := unstructured.SetNestedField(clone.Object, int64(spec.GetReplicas()), "spec", "replicas"); err != nil {
+			return nil, err
+		}
+	}
+	if spec.StorageClassName != nil {
+		if err := overrideStorageClass(clone, *spec.StorageClassName); err != nil {
+			return nil, err
+		}
+	}
+	if clone.GroupVersionKind().Group == kubedb.GroupName {
+		if err := unstructured.SetNestedField(clone.Object, string(dbapi.DeletionPolicyWipeOut), "spec", "deletionPolicy"); err != nil {
+			return nil, err
+		}
+		unstructured.RemoveNestedField(clone.Object, "spec", "terminationPolicy")
+		unstructured.RemoveNestedField(clone.Object, "spec", "halted")
+	}
+	return clone, nil
+}
+
+// overrideStorageClass overrides the storage class of the KubeDB storage spec and the volume claim templates of the clone
+func overrideStorageClass(clone *unstructured.Unstructured, storageClass string) error {
+	if _, found, _ := unstructured.NestedMap(clone.Object, "spec", "storage"); found {
+		if err := unstructured.SetNestedField(clone.Object, storageClass, "spec", "storage", "storageClassName"); err != nil {
+			return err
+		}
+	}
+
+	templates, found, err := unstructured.NestedSlice(clone.Object, "spec", "volumeClaimTemplates")
+	if err != nil || !found {
+		return err
+	}
+	for i := range templates {
+		template, ok := templates[i].(map[string]any)
+		if !ok {
+			continue
+		}
+		if err := unstructured.SetNestedField(template, storageClass, "spec", "storageClassName"); err != nil {
+			return err
+		}
+	}
+	return unstructured.SetNestedSlice(clone.Object, templates, "spec", "volumeClaimTemplates")
+}
+
+// Teardown deletes the sandbox namespace along with everything in it
+func Teardown(ctx context.Context, c client.Client, status *coreapi.SandboxStatus) error {
+	ns := &core.Namespace{ObjectMeta: metav1.ObjectMeta{Name: status.Namespace}}
+	if err := c.Delete(ctx, ns); err != nil {
+		if kerr.IsNotFound(err) {
+			status.Phase = coreapi.SandboxDeleted
+			return nil
+		}
+		return fmt.Errorf("failed to delete sandbox namespace %s: %w", status.Namespace, err)
+	}
+	status.Phase = coreapi.SandboxDeleting
+	return nil
+}
+
+// CleanupExpired deletes the sandbox namespaces managed by KubeStash whose TTL has expired, including the ones
+// left behind by the verifications that were interrupted. It returns the names of the deleted namespaces.
+func CleanupExpired(ctx context.Context, c client.Client, now time.Time) ([]string, error) {
+	namespaces := &core.NamespaceList{}
+	if err := c.List(ctx, namespaces, client.MatchingLabels{
+		apis.KubeStashSandbox:       "true",
+		meta_util.ManagedByLabelKey: apis.KubeStashKey,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list sandbox namespaces: %w", err)
+	}
+
+	var deleted []string
+	var errs []error
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		if ns.DeletionTimestamp != nil || !isExpired(ns, now) {
+			continue
+		}
+		if err := c.Delete(ctx, ns); err != nil && !kerr.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete sandbox namespace %s: %w", ns.Name, err))
+			continue
+		}
+		deleted = append(deleted, ns.Name)
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// isExpired returns whether the TTL of the sandbox namespace has expired. A namespace with a missing
+// or malformed expiry time is never considered expired, as it may not have been created by KubeStash.
+func isExpired(ns *core.Namespace, now time.Time) bool {
+	expiry, err := getTime(ns, apis.KeySandboxExpiryTime)
+	if err != nil {
+		klog.Warningf("Skipping the cleanup of sandbox namespace %s. Reason: %v", ns.Name, err)
+		return false
+	}
+	return !now.Before(expiry)
+}
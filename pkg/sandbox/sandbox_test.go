@@ -0,0 +1,162 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sandbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestProvision(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithObjects(&core.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: metav1.NamespaceDefault},
+		Subsets: []core.EndpointSubset{
+			{
+				Addresses: []core.EndpointAddress{{IP: "172.18.0.2"}},
+				Ports:     []core.EndpointPort{{Name: "https", Port: 6443}},
+			},
+		},
+	}).Build()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	session := &coreapi.BackupVerificationSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-repo-frequent-1700000000", Namespace: "demo"},
+	}
+	spec := &coreapi.SandboxSpec{
+		StorageClassName: ptr.To("standard"),
+		ResourceQuota:    core.ResourceList{core.ResourceRequestsStorage: resource.MustParse("10Gi")},
+	}
+	target := sampleStatefulSet(t)
+
+	status, err := Provision(ctx, c, spec, session, target, now)
+	assert.Nil(t, err)
+	assert.Equal(t, coreapi.SandboxReady, status.Phase)
+	assert.Equal(t, NamespaceName(spec, session), status.Namespace)
+	assert.Equal(t, now.Add(apis.DefaultSandboxTTL), status.ExpiryTime.Time)
+
+	resumed, err := Provision(ctx, c, spec, session, target, now.Add(time.Hour))
+	assert.Nil(t, err, "provisioning should be resumable")
+	assert.True(t, status.CreationTime.Equal(resumed.CreationTime), "resuming should keep the creation time")
+	assert.True(t, status.ExpiryTime.Equal(resumed.ExpiryTime), "resuming should keep the expiry time")
+
+	policy := &networking.NetworkPolicy{}
+	assert.Nil(t, c.Get(ctx, client.ObjectKey{Name: apis.SandboxNetworkPolicyName, Namespace: status.Namespace}, policy))
+	assert.Contains(t, policy.Spec.PolicyTypes, networking.PolicyTypeEgress)
+	assert.Contains(t, policy.Spec.Ingress[0].From, networking.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{core.LabelMetadataName: apis.DefaultSandboxOperatorNamespace}},
+	})
+	assert.Contains(t, policy.Spec.Egress, networking.NetworkPolicyEgressRule{
+		To:    []networking.NetworkPolicyPeer{{IPBlock: &networking.IPBlock{CIDR: "172.18.0.2/32"}}},
+		Ports: []networking.NetworkPolicyPort{{Protocol: ptr.To(core.ProtocolTCP), Port: ptr.To(intstr.FromInt32(6443))}},
+	})
+	assert.Nil(t, c.Get(ctx, client.ObjectKey{Name: apis.SandboxRestoreEgressPolicyName, Namespace: status.Namespace}, &networking.NetworkPolicy{}))
+	assert.Nil(t, c.Get(ctx, client.ObjectKey{Name: apis.SandboxResourceQuotaName, Namespace: status.Namespace}, &core.ResourceQuota{}))
+
+	clone := &apps.StatefulSet{}
+	assert.Nil(t, c.Get(ctx, client.ObjectKey{Name: "mysql", Namespace: status.Namespace}, clone))
+	assert.Equal(t, int32(1), *clone.Spec.Replicas)
+	assert.Equal(t, "standard", *clone.Spec.VolumeClaimTemplates[0].Spec.StorageClassName)
+
+	assert.Nil(t, Teardown(ctx, c, status))
+	assert.Equal(t, coreapi.SandboxDeleting, status.Phase)
+}
+
+func TestCleanupExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	spec := &coreapi.SandboxSpec{TTL: &metav1.Duration{Duration: time.Hour}}
+
+	expired := NewNamespace(spec, &coreapi.BackupVerificationSession{ObjectMeta: metav1.ObjectMeta{Name: "crashed", Namespace: "demo"}}, now.Add(-2*time.Hour))
+	active := NewNamespace(spec, &coreapi.BackupVerificationSession{ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "demo"}}, now)
+	unrelated := &core.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "demo"}}
+	unmanaged := &core.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "unmanaged",
+		Labels: map[string]string{apis.KubeStashSandbox: "true"},
+	}}
+	untracked := NewNamespace(spec, &coreapi.BackupVerificationSession{ObjectMeta: metav1.ObjectMeta{Name: "untracked", Namespace: "demo"}}, now.Add(-2*time.Hour))
+	delete(untracked.Annotations, apis.KeySandboxExpiryTime)
+	c := fake.NewClientBuilder().WithObjects(expired, active, unrelated, unmanaged, untracked).Build()
+
+	deleted, err := CleanupExpired(ctx, c, now)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{expired.Name}, deleted)
+
+	namespaces := &core.NamespaceList{}
+	assert.Nil(t, c.List(ctx, namespaces))
+	assert.Len(t, namespaces.Items, 4)
+}
+
+func TestCloneTargetWipesOutKubeDBDatabase(t *testing.T) {
+	db := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"version":        "8.0.35",
+			"deletionPolicy": "DoNotTerminate",
+			"halted":         true,
+		},
+	}}
+	db.SetAPIVersion("kubedb.com/v1")
+	db.SetKind("MySQL")
+	db.SetName("mysql")
+	db.SetNamespace("demo")
+
+	clone, err := CloneTarget(db, "kubestash-sandbox-1234", &coreapi.SandboxSpec{})
+	assert.Nil(t, err)
+	policy, _, _ := unstructured.NestedString(clone.Object, "spec", "deletionPolicy")
+	assert.Equal(t, "WipeOut", policy)
+	_, found, _ := unstructured.NestedFieldNoCopy(clone.Object, "spec", "halted")
+	assert.False(t, found)
+	policy, _, _ = unstructured.NestedString(db.Object, "spec", "deletionPolicy")
+	assert.Equal(t, "DoNotTerminate", policy, "the source should not be modified")
+}
+
+func sampleStatefulSet(t *testing.T) *unstructured.Unstructured {
+	sts := &apps.StatefulSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql", Namespace: "demo", UID: "uid", ResourceVersion: "42"},
+		Spec: apps.StatefulSetSpec{
+			Replicas: ptr.To(int32(3)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "mysql"}},
+			VolumeClaimTemplates: []core.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "data"},
+					Spec:       core.PersistentVolumeClaimSpec{StorageClassName: ptr.To("premium")},
+				},
+			},
+		},
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(sts)
+	assert.Nil(t, err)
+	return &unstructured.Unstructured{Object: obj}
+}
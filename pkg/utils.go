@@ -26,6 +26,7 @@ import (
 	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
 	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
 
+	vgsapi "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta2"
 	vsapi "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	"gomodules.xyz/envsubst"
 	apps "k8s.io/api/apps/v1"
@@ -62,6 +63,7 @@ func NewUncachedClient(clientGetter ...genericclioptions.RESTClientGetter) (clie
 		coreapi.AddToScheme,
 		addonapi.AddToScheme,
 		vsapi.AddToScheme,
+		vgsapi.AddToScheme,
 		core.AddToScheme,
 		apps.AddToScheme,
 		storagev1.AddToScheme,
@@ -98,6 +100,22 @@ func NewVolumeSnapshot(meta metav1.ObjectMeta, pvcName, vsClassName string) *vsa
 	return volSnapshot
 }
 
+// NewVolumeGroupSnapshot returns a VolumeGroupSnapshot that snapshots all the PVCs selected by the selector at the same instant.
+func NewVolumeGroupSnapshot(meta metav1.ObjectMeta, selector *metav1.LabelSelector, vgsClassName string) *vgsapi.VolumeGroupSnapshot {
+	groupSnapshot := &vgsapi.VolumeGroupSnapshot{
+		ObjectMeta: meta,
+		Spec: vgsapi.VolumeGroupSnapshotSpec{
+			Source: vgsapi.VolumeGroupSnapshotSource{
+				Selector: selector,
+			},
+		},
+	}
+	if vgsClassName != "" {
+		groupSnapshot.Spec.VolumeGroupSnapshotClassName = &vgsClassName
+	}
+	return groupSnapshot
+}
+
 func NewVolumeSnapshotDataSource(snapshotName string) *core.TypedLocalObjectReference {
 	return &core.TypedLocalObjectReference{
 		APIGroup: &vsapi.SchemeGroupVersion.Group,
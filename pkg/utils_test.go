@@ -0,0 +1,42 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"testing"
+
+	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+)
+
+func TestResolveWithHookOutputs(t *testing.T) {
+	status := coreapi.HookStatus{
+		PreHooks: []coreapi.HookExecutionStatus{
+			{
+				Name:    "flush-logs",
+				Phase:   coreapi.HookExecutionSucceeded,
+				Outputs: []coreapi.HookOutput{{Name: "binlog-position", Value: "mysql-bin.000042:154"}},
+			},
+		},
+	}
+
+	env := []core.EnvVar{{Name: "START_POSITION", Value: "${HOOK_PRE_FLUSH_LOGS__BINLOG_POSITION}"}}
+	assert.Nil(t, ResolveWithInputs(&env, status.GetOutputVariables()))
+	assert.Equal(t, "mysql-bin.000042:154", env[0].Value)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
+	"kubestash.dev/apimachinery/pkg/blob"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	reportContentType = "application/json"
+	signatureSuffix   = ".sig"
+)
+
+// Report is the exportable verification report of a BackupVerificationSession
+type Report struct {
+	Session      string                                 `json:"session"`
+	Namespace    string                                 `json:"namespace"`
+	Invoker      *core.TypedLocalObjectReference        `json:"invoker,omitempty"`
+	Repository   string                                 `json:"repository"`
+	Snapshot     string                                 `json:"snapshot"`
+	Phase        coreapi.BackupVerificationSessionPhase `json:"phase"`
+	Duration     string                                 `json:"duration,omitempty"`
+	GeneratedAt  metav1.Time                            `json:"generatedAt"`
+	Evidence     *coreapi.VerificationReport            `json:"evidence,omitempty"`
+	QueryResults []coreapi.QueryResult                  `json:"queryResults,omitempty"`
+}
+
+// NewReport builds the verification report from the status of the given BackupVerificationSession.
+// The artifact information is not included in the report.
+func NewReport(session *coreapi.BackupVerificationSession, now time.Time) *Report {
+	report := &Report{
+		Session:      session.Name,
+		Namespace:    session.Namespace,
+		Invoker:      session.Spec.Invoker,
+		Repository:   session.Spec.Repository,
+		Snapshot:     session.Spec.Snapshot,
+		Phase:        session.Status.Phase,
+		Duration:     session.Status.Duration,
+		GeneratedAt:  metav1.Time{Time: now.UTC()},
+		QueryResults: session.Status.QueryResults,
+	}
+	if session.Status.Report != nil {
+		report.Evidence = session.Status.Report.DeepCopy()
+		report.Evidence.Artifact = nil
+	}
+	return report
+}
+
+// ReportPath returns the path of the report of the given BackupVerificationSession relative to the given directory
+func ReportPath(dir string, session *coreapi.BackupVerificationSession) string {
+	return path.Join(dir, apis.DirVerificationReports, session.Name+".json")
+}
+
+// Sign encodes the report and signs it with HMAC-SHA256 using the given key.
+// It returns the encoded report, its SHA-256 digest and the signature.
+func Sign(report *Report, key []byte) ([]byte, string, string, error) {
+	if len(key) == 0 {
+		return nil, "", "", fmt.Errorf("signing key can not be empty")
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to encode verification report: %w", err)
+	}
+	digest := sha256.Sum256(data)
+	return data, "sha256:" + hex.EncodeToString(digest[:]), computeSignature(data, key), nil
+}
+
+// VerifySignature returns whether the signature of the encoded report is valid for the given key
+func VerifySignature(data []byte, signature string, key []byte) bool {
+	return hmac.Equal([]byte(computeSignature(data, key)), []byte(signature))
+}
+
+func computeSignature(data, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Upload signs the report of the given BackupVerificationSession and uploads it to the BackupStorage
+// along with its detached signature. The report is stored under the given directory.
+func Upload(ctx context.Context, b *blob.Blob, dir string, session *coreapi.BackupVerificationSession, key []byte, now time.Time) (*coreapi.VerificationReportArtifact, error) {
+	data, digest, signature, err := Sign(NewReport(session, now), key)
+	if err != nil {
+		return nil, err
+	}
+
+	reportPath := ReportPath(dir, session)
+	if err := b.Upload(ctx, reportPath, data, reportContentType); err != nil {
+		return nil, fmt.Errorf("failed to upload verification report %s: %w", reportPath, err)
+	}
+	if err := b.Upload(ctx, reportPath+signatureSuffix, []byte(signature), "text/plain"); err != nil {
+		return nil, fmt.Errorf("failed to upload signature of verification report %s: %w", reportPath, err)
+	}
+
+	return &coreapi.VerificationReportArtifact{
+		Path:       reportPath,
+		Digest:     digest,
+		Signature:  signature,
+		UploadTime: &metav1.Time{Time: now},
+	}, nil
+}
+
+// Download reads the report from the BackupStorage and verifies its signature using the given key
+func Download(ctx context.Context, b *blob.Blob, reportPath string, key []byte) (*Report, error) {
+	data, err := b.Get(ctx, reportPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read verification report %s: %w", reportPath, err)
+	}
+	signature, err := b.Get(ctx, reportPath+signatureSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signature of verification report %s: %w", reportPath, err)
+	}
+	if !VerifySignature(data, string(signature), key) {
+		return nil, fmt.Errorf("signature of verification report %s is invalid", reportPath)
+	}
+
+	report := &Report{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("failed to decode verification report %s: %w", reportPath, err)
+	}
+	return report, nil
+}
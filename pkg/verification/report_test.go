@@ -0,0 +1,88 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	coreapi "kubestash.dev/apimachinery/apis/core/v1alpha1"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+	"kubestash.dev/apimachinery/pkg/blob"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+func TestUploadReport(t *testing.T) {
+	ctx := context.Background()
+	b, err := blob.NewBlob(ctx, nil, &storageapi.BackupStorage{
+		Spec: storageapi.BackupStorageSpec{
+			Storage: storageapi.Backend{
+				Provider: storageapi.ProviderLocal,
+				Local: &storageapi.LocalSpec{
+					MountPath: t.TempDir(),
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	now := time.Now()
+	session := &coreapi.BackupVerificationSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-repo-frequent-1700000000", Namespace: "demo"},
+		Spec: coreapi.BackupVerificationSessionSpec{
+			Repository: "s3-repo",
+			Snapshot:   "s3-repo-mysql-frequent-1700000000",
+		},
+		Status: coreapi.BackupVerificationSessionStatus{
+			Phase: coreapi.BackupVerificationSessionSucceeded,
+			QueryResults: []coreapi.QueryResult{
+				{Name: "users", Phase: coreapi.QueryPassed, Expected: "Equal 10", Actual: "10"},
+			},
+			Report: &coreapi.VerificationReport{
+				RestoreSession:    "s3-repo-frequent-1700000000-restore",
+				RestoreTarget:     &kmapi.TypedObjectReference{APIGroup: "kubedb.com", Kind: "MySQL", Namespace: "verify", Name: "mysql"},
+				RestoredSize:      "1.2 GiB",
+				RestoredSizeBytes: 1288490189,
+			},
+		},
+	}
+	session.Status.Report.SetRestoreDuration(now.Add(-90*time.Second), now)
+	assert.Equal(t, "1m30s", session.Status.Report.RestoreDuration)
+
+	key := []byte("report-signing-key")
+	artifact, err := Upload(ctx, b, "demo/mysql", session, key, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "demo/mysql/verification-reports/s3-repo-frequent-1700000000.json", artifact.Path)
+	assert.Contains(t, artifact.Digest, "sha256:")
+
+	report, err := Download(ctx, b, artifact.Path, key)
+	assert.Nil(t, err)
+	assert.Equal(t, session.Spec.Snapshot, report.Snapshot)
+	assert.Equal(t, session.Status.QueryResults, report.QueryResults)
+	assert.Equal(t, "mysql", report.Evidence.RestoreTarget.Name)
+
+	_, err = Download(ctx, b, artifact.Path, []byte("another-key"))
+	assert.NotNil(t, err, "the report should not be trusted with a different key")
+
+	assert.Nil(t, b.Upload(ctx, artifact.Path, []byte(`{"phase":"Succeeded"}`), reportContentType))
+	_, err = Download(ctx, b, artifact.Path, key)
+	assert.NotNil(t, err, "a tampered report should be rejected")
+}
@@ -0,0 +1,150 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"context"
+	"fmt"
+
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+
+	vgsapi "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta2"
+	vsapi "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetVolumeGroupSnapshotStats returns the information of a ready VolumeGroupSnapshot along with the
+// VolumeSnapshots the CSI snapshot controller has created for the individual PVCs of the group.
+// The VolumeSnapshots are mapped back to their PVCs using the CSI volume and snapshot handles
+// recorded in the bound VolumeGroupSnapshotContent.
+func GetVolumeGroupSnapshotStats(ctx context.Context, c client.Client, vgs *vgsapi.VolumeGroupSnapshot) (*storageapi.VolumeGroupSnapshotStats, error) {
+	if vgs.Status == nil || !ptr.Deref(vgs.Status.ReadyToUse, false) || vgs.Status.BoundVolumeGroupSnapshotContentName == nil {
+		return nil, fmt.Errorf("VolumeGroupSnapshot %s/%s is not ready to use", vgs.Namespace, vgs.Name)
+	}
+
+	content := &vgsapi.VolumeGroupSnapshotContent{}
+	if err := c.Get(ctx, client.ObjectKey{Name: *vgs.Status.BoundVolumeGroupSnapshotContentName}, content); err != nil {
+		return nil, err
+	}
+	if content.Status == nil {
+		return nil, fmt.Errorf("VolumeGroupSnapshotContent %s has no status", content.Name)
+	}
+	snapshotHandles := make(map[string]string)
+	for _, info := range content.Status.VolumeSnapshotInfoList {
+		snapshotHandles[info.VolumeHandle] = info.SnapshotHandle
+	}
+
+	members, err := getVolumeGroupSnapshotMembers(ctx, c, vgs)
+	if err != nil {
+		return nil, err
+	}
+
+	pvcs, err := getVolumeGroupSnapshotPVCs(ctx, c, vgs)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &storageapi.VolumeGroupSnapshotStats{
+		Name:                         vgs.Name,
+		VolumeGroupSnapshotClassName: ptr.Deref(vgs.Spec.VolumeGroupSnapshotClassName, ""),
+		CreationTime:                 vgs.Status.CreationTime,
+	}
+	for _, pvc := range pvcs {
+		volumeHandle, err := getVolumeHandle(ctx, c, pvc)
+		if err != nil {
+			return nil, err
+		}
+		vsName, found := members[snapshotHandles[volumeHandle]]
+		if !found {
+			return nil, fmt.Errorf("no VolumeSnapshot found for PVC %s/%s in VolumeGroupSnapshot %s", pvc.Namespace, pvc.Name, vgs.Name)
+		}
+		stats.Volumes = append(stats.Volumes, storageapi.VolumeSnapshotterStats{
+			PVCName:            pvc.Name,
+			VolumeSnapshotName: vsName,
+			VolumeSnapshotTime: vgs.Status.CreationTime,
+		})
+	}
+	return stats, nil
+}
+
+// NewVolumeSnapshotDataSourceForPVC returns the data source to restore the given PVC from the VolumeSnapshots recorded in the component.
+func NewVolumeSnapshotDataSourceForPVC(comp storageapi.Component, pvcName string) (*core.TypedLocalObjectReference, error) {
+	vs, found := comp.GetVolumeSnapshots()[pvcName]
+	if !found {
+		return nil, fmt.Errorf("no VolumeSnapshot found for PVC %q", pvcName)
+	}
+	return NewVolumeSnapshotDataSource(vs.VolumeSnapshotName), nil
+}
+
+// getVolumeGroupSnapshotMembers returns the names of the VolumeSnapshots of the group indexed by their snapshot handles
+func getVolumeGroupSnapshotMembers(ctx context.Context, c client.Client, vgs *vgsapi.VolumeGroupSnapshot) (map[string]string, error) {
+	vsList := &vsapi.VolumeSnapshotList{}
+	if err := c.List(ctx, vsList, client.InNamespace(vgs.Namespace)); err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]string)
+	for _, vs := range vsList.Items {
+		if vs.Status == nil || ptr.Deref(vs.Status.VolumeGroupSnapshotName, "") != vgs.Name || vs.Status.BoundVolumeSnapshotContentName == nil {
+			continue
+		}
+		vsc := &vsapi.VolumeSnapshotContent{}
+		if err := c.Get(ctx, client.ObjectKey{Name: *vs.Status.BoundVolumeSnapshotContentName}, vsc); err != nil {
+			return nil, err
+		}
+		handle := vsc.Spec.Source.SnapshotHandle
+		if vsc.Status != nil && vsc.Status.SnapshotHandle != nil {
+			handle = vsc.Status.SnapshotHandle
+		}
+		if handle != nil {
+			members[*handle] = vs.Name
+		}
+	}
+	return members, nil
+}
+
+func getVolumeGroupSnapshotPVCs(ctx context.Context, c client.Client, vgs *vgsapi.VolumeGroupSnapshot) ([]core.PersistentVolumeClaim, error) {
+	if vgs.Spec.Source.Selector == nil {
+		return nil, fmt.Errorf("VolumeGroupSnapshot %s/%s has no PVC selector", vgs.Namespace, vgs.Name)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(vgs.Spec.Source.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pvcList := &core.PersistentVolumeClaimList{}
+	if err := c.List(ctx, pvcList, client.InNamespace(vgs.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	return pvcList.Items, nil
+}
+
+func getVolumeHandle(ctx context.Context, c client.Client, pvc core.PersistentVolumeClaim) (string, error) {
+	if pvc.Spec.VolumeName == "" {
+		return "", fmt.Errorf("PVC %s/%s is not bound to any volume", pvc.Namespace, pvc.Name)
+	}
+	pv := &core.PersistentVolume{}
+	if err := c.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, pv); err != nil {
+		return "", err
+	}
+	if pv.Spec.CSI == nil {
+		return "", fmt.Errorf("volume %s of PVC %s/%s is not provisioned by a CSI driver", pv.Name, pvc.Namespace, pvc.Name)
+	}
+	return pv.Spec.CSI.VolumeHandle, nil
+}
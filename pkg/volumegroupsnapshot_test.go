@@ -0,0 +1,162 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"kubestash.dev/apimachinery/apis"
+	storageapi "kubestash.dev/apimachinery/apis/storage/v1alpha1"
+
+	vgsapi "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta2"
+	vsapi "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetVolumeGroupSnapshotStats(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeSnapshotClient(t, "data", "wal")
+
+	vgs := NewVolumeGroupSnapshot(
+		metav1.ObjectMeta{Name: "mysql-backup", Namespace: "demo"},
+		&metav1.LabelSelector{MatchLabels: map[string]string{"app": "mysql"}},
+		"csi-group",
+	)
+	assert.Nil(t, c.Create(ctx, vgs))
+
+	_, err := GetVolumeGroupSnapshotStats(ctx, c, vgs)
+	assert.NotNil(t, err, "the group snapshot should not be used before it is ready")
+
+	fakeSnapshotController(t, c, vgs)
+
+	stats, err := GetVolumeGroupSnapshotStats(ctx, c, vgs)
+	assert.Nil(t, err)
+	assert.Equal(t, "mysql-backup", stats.Name)
+	assert.Equal(t, "csi-group", stats.VolumeGroupSnapshotClassName)
+	assert.Len(t, stats.Volumes, 2)
+
+	comp := storageapi.Component{VolumeGroupSnapshotStats: stats}
+	for _, pvc := range []string{"data", "wal"} {
+		ds, err := NewVolumeSnapshotDataSourceForPVC(comp, pvc)
+		assert.Nil(t, err)
+		assert.Equal(t, apis.KindVolumeSnapshot, ds.Kind)
+		assert.Equal(t, "snapshot-"+pvc, ds.Name)
+		assert.Equal(t, stats.CreationTime, comp.GetVolumeSnapshots()[pvc].VolumeSnapshotTime)
+	}
+
+	_, err = NewVolumeSnapshotDataSourceForPVC(comp, "unknown")
+	assert.NotNil(t, err)
+}
+
+func newFakeSnapshotClient(t *testing.T, pvcs ...string) client.Client {
+	scheme := runtime.NewScheme()
+	assert.Nil(t, core.AddToScheme(scheme))
+	assert.Nil(t, vsapi.AddToScheme(scheme))
+	assert.Nil(t, vgsapi.AddToScheme(scheme))
+
+	var objs []client.Object
+	for _, name := range pvcs {
+		objs = append(objs,
+			&core.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "demo", Labels: map[string]string{"app": "mysql"}},
+				Spec:       core.PersistentVolumeClaimSpec{VolumeName: "pv-" + name},
+			},
+			&core.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-" + name},
+				Spec: core.PersistentVolumeSpec{
+					PersistentVolumeSource: core.PersistentVolumeSource{
+						CSI: &core.CSIPersistentVolumeSource{Driver: "hostpath.csi.k8s.io", VolumeHandle: "volume-" + name},
+					},
+				},
+			},
+		)
+	}
+	// an unrelated PVC that should not be part of the group
+	objs = append(objs, &core.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "demo"},
+	})
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&vgsapi.VolumeGroupSnapshot{}, &vsapi.VolumeSnapshot{}).
+		Build()
+}
+
+// fakeSnapshotController mimics the CSI snapshot controller by creating the VolumeGroupSnapshotContent
+// and the individual VolumeSnapshots for the PVCs selected by the VolumeGroupSnapshot.
+func fakeSnapshotController(t *testing.T, c client.Client, vgs *vgsapi.VolumeGroupSnapshot) {
+	ctx := context.Background()
+	pvcs, err := getVolumeGroupSnapshotPVCs(ctx, c, vgs)
+	assert.Nil(t, err)
+
+	creationTime := metav1.NewTime(time.Now().Truncate(time.Second))
+	content := &vgsapi.VolumeGroupSnapshotContent{
+		ObjectMeta: metav1.ObjectMeta{Name: "groupsnapcontent-" + vgs.Name},
+		Status: &vgsapi.VolumeGroupSnapshotContentStatus{
+			ReadyToUse:   ptr.To(true),
+			CreationTime: &creationTime,
+		},
+	}
+
+	for _, pvc := range pvcs {
+		snapshotHandle := fmt.Sprintf("snapshot-handle-%s", pvc.Name)
+		content.Status.VolumeSnapshotInfoList = append(content.Status.VolumeSnapshotInfoList, vgsapi.VolumeSnapshotInfo{
+			VolumeHandle:   "volume-" + pvc.Name,
+			SnapshotHandle: snapshotHandle,
+		})
+
+		vsc := &vsapi.VolumeSnapshotContent{
+			ObjectMeta: metav1.ObjectMeta{Name: "snapcontent-" + pvc.Name},
+			Spec: vsapi.VolumeSnapshotContentSpec{
+				Source: vsapi.VolumeSnapshotContentSource{SnapshotHandle: &snapshotHandle},
+			},
+		}
+		assert.Nil(t, c.Create(ctx, vsc))
+
+		vs := &vsapi.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "snapshot-" + pvc.Name, Namespace: vgs.Namespace},
+			Spec: vsapi.VolumeSnapshotSpec{
+				Source: vsapi.VolumeSnapshotSource{VolumeSnapshotContentName: &vsc.Name},
+			},
+		}
+		assert.Nil(t, c.Create(ctx, vs))
+		vs.Status = &vsapi.VolumeSnapshotStatus{
+			BoundVolumeSnapshotContentName: &vsc.Name,
+			VolumeGroupSnapshotName:        &vgs.Name,
+			ReadyToUse:                     ptr.To(true),
+		}
+		assert.Nil(t, c.Status().Update(ctx, vs))
+	}
+	assert.Nil(t, c.Create(ctx, content))
+
+	vgs.Status = &vgsapi.VolumeGroupSnapshotStatus{
+		BoundVolumeGroupSnapshotContentName: &content.Name,
+		CreationTime:                        &creationTime,
+		ReadyToUse:                          ptr.To(true),
+	}
+	assert.Nil(t, c.Status().Update(ctx, vgs))
+}
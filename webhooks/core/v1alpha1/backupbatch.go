@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/apis/core/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -43,12 +44,30 @@ type (
 func SetupBackupBatchWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.BackupBatch{}).
 		WithValidator(&BackupBatchCustomWebhook{}).
-		// WithDefaulter(&BackupBatchCustomWebhook{}).
+		WithDefaulter(&BackupBatchCustomWebhook{}).
 		Complete()
 }
 
 // TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 
+//+kubebuilder:webhook:path=/mutate-core-kubestash-com-v1alpha1-backupbatch,mutating=true,failurePolicy=fail,sideEffects=None,groups=core.kubestash.com,resources=backupbatches,verbs=create;update,versions=v1alpha1,name=mbackupbatch.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomDefaulter = &BackupBatchCustomWebhook{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (*BackupBatchCustomWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	var ok bool
+	var b BackupBatch
+	b.BackupBatch, ok = obj.(*v1alpha1.BackupBatch)
+	if !ok {
+		return fmt.Errorf("expected BackupBatch but got %T", obj)
+	}
+	backupbatchlog.Info("default", "name", b.Name)
+
+	b.Spec.PauseInfo = apis.DefaultPauseInfo(ctx, b.Spec.Paused, b.Spec.PauseInfo)
+	return nil
+}
+
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 //+kubebuilder:webhook:path=/validate-core-kubestash-com-v1alpha1-backupbatch,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.kubestash.com,resources=backupbatches,verbs=create;update,versions=v1alpha1,name=vbackupbatch.kb.io,admissionReviewVersions=v1
 
@@ -64,8 +83,19 @@ func (*BackupBatchCustomWebhook) ValidateCreate(ctx context.Context, obj runtime
 	}
 	backupbatchlog.Info("Validation for BackupBatch upon creation", "name", b.Name)
 
-	// TODO(user): fill in your validation logic upon object creation.
-	return nil, nil
+	if err := b.validateStages(); err != nil {
+		return nil, err
+	}
+	if err := b.validateHooks(); err != nil {
+		return nil, err
+	}
+	if err := b.validateSchedulers(); err != nil {
+		return nil, err
+	}
+	if err := b.validateSuccessPolicies(); err != nil {
+		return nil, err
+	}
+	return nil, validatePauseInfo(b.Spec.PauseInfo, getSessionNames(b.Spec.Sessions))
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -83,8 +113,19 @@ func (*BackupBatchCustomWebhook) ValidateUpdate(ctx context.Context, oldObj, new
 		return nil, fmt.Errorf("expected BackupBatch but got %T", oldObj)
 	}
 
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	if err := bNew.validateStages(); err != nil {
+		return nil, err
+	}
+	if err := bNew.validateHooks(); err != nil {
+		return nil, err
+	}
+	if err := bNew.validateSchedulers(); err != nil {
+		return nil, err
+	}
+	if err := bNew.validateSuccessPolicies(); err != nil {
+		return nil, err
+	}
+	return nil, validatePauseInfo(bNew.Spec.PauseInfo, getSessionNames(bNew.Spec.Sessions))
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -100,3 +141,99 @@ func (*BackupBatchCustomWebhook) ValidateDelete(ctx context.Context, obj runtime
 	// TODO(user): fill in your validation logic upon object deletion.
 	return nil, nil
 }
+
+func (b *BackupBatch) validateStages() error {
+	for _, session := range b.Spec.Sessions {
+		if session.SessionConfig == nil || len(session.Stages) == 0 {
+			continue
+		}
+
+		targets := make(map[string]bool)
+		for _, t := range session.Targets {
+			targets[t.Name] = false
+		}
+
+		stages := make(map[string]bool)
+		for _, stage := range session.Stages {
+			if stage.Name == "" {
+				return fmt.Errorf("stage name can not be empty for session: %q", session.Name)
+			}
+			if stages[stage.Name] {
+				return fmt.Errorf("duplicate stage %q for session: %q", stage.Name, session.Name)
+			}
+			stages[stage.Name] = true
+
+			if stage.Parallelism != nil && *stage.Parallelism < 1 {
+				return fmt.Errorf("parallelism of stage %q must be at least 1 for session: %q", stage.Name, session.Name)
+			}
+
+			for _, target := range stage.Targets {
+				assigned, found := targets[target]
+				if !found {
+					return fmt.Errorf("stage %q refers to unknown target %q for session: %q", stage.Name, target, session.Name)
+				}
+				if assigned {
+					return fmt.Errorf("target %q is assigned to multiple stages for session: %q", target, session.Name)
+				}
+				targets[target] = true
+			}
+		}
+
+		for _, t := range session.Targets {
+			if !targets[t.Name] {
+				return fmt.Errorf("target %q is not assigned to any stage for session: %q", t.Name, session.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (b *BackupBatch) validateHooks() error {
+	for _, session := range b.Spec.Sessions {
+		if session.SessionConfig == nil {
+			continue
+		}
+		for _, hooks := range []*v1alpha1.BackupHooks{session.Hooks, session.BatchHooks} {
+			if hooks == nil {
+				continue
+			}
+			if err := validateUndoHooks(hooks.PreBackup, hooks.PostBackup); err != nil {
+				return fmt.Errorf("invalid hooks for session: %q. Reason: %w", session.Name, err)
+			}
+			if err := v1alpha1.ValidateHookNames(hooks.PreBackup, hooks.PostBackup); err != nil {
+				return fmt.Errorf("invalid hooks for session: %q. Reason: %w", session.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (b *BackupBatch) validateSchedulers() error {
+	for _, session := range b.Spec.Sessions {
+		if session.SessionConfig == nil || session.Scheduler == nil {
+			continue
+		}
+		// the effective schedule is resolved only for the sessions of a BackupConfiguration
+		if session.Scheduler.HasHashedFields() {
+			return fmt.Errorf("invalid schedule %q for session: %q. Reason: \"H\" fields are not supported for BackupBatch", session.Scheduler.Schedule, session.Name)
+		}
+	}
+	return nil
+}
+
+func (b *BackupBatch) validateSuccessPolicies() error {
+	for _, session := range b.Spec.Sessions {
+		if session.SessionConfig == nil {
+			continue
+		}
+		// the Snapshots of a batch BackupSession are taken in the Repositories of all the targets
+		var repositories []v1alpha1.RepositoryInfo
+		for _, target := range session.Targets {
+			repositories = append(repositories, target.Repositories...)
+		}
+		if err := validateSuccessPolicy(session.Name, session.SuccessPolicy, repositories); err != nil {
+			return err
+		}
+	}
+	return nil
+}
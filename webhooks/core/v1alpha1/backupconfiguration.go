@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/apis/core/v1alpha1"
@@ -76,6 +77,7 @@ func (*BackupConfigurationCustomWebhook) Default(ctx context.Context, obj runtim
 	}
 
 	b.setDefaultRetentionPolicy(ctx, c)
+	b.Spec.PauseInfo = apis.DefaultPauseInfo(ctx, b.Spec.Paused, b.Spec.PauseInfo)
 	return nil
 }
 
@@ -103,11 +105,19 @@ func (*BackupConfigurationCustomWebhook) ValidateCreate(ctx context.Context, obj
 		return nil, err
 	}
 
+	if err := validatePauseInfo(b.Spec.PauseInfo, getSessionNames(b.Spec.Sessions)); err != nil {
+		return nil, err
+	}
+
 	if err := b.validateBackendsAgainstUsagePolicy(ctx, c); err != nil {
 		return nil, err
 	}
 
-	return nil, b.validateHookTemplatesAgainstUsagePolicy(ctx, c)
+	if err := b.validateHookTemplatesAgainstUsagePolicy(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return nil, b.validateHookParams(ctx, c)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -134,11 +144,19 @@ func (*BackupConfigurationCustomWebhook) ValidateUpdate(ctx context.Context, old
 		return nil, err
 	}
 
+	if err := validatePauseInfo(bNew.Spec.PauseInfo, getSessionNames(bNew.Spec.Sessions)); err != nil {
+		return nil, err
+	}
+
 	if err := bNew.validateBackendsAgainstUsagePolicy(ctx, c); err != nil {
 		return nil, err
 	}
 
-	return nil, bNew.validateHookTemplatesAgainstUsagePolicy(ctx, c)
+	if err := bNew.validateHookTemplatesAgainstUsagePolicy(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return nil, bNew.validateHookParams(ctx, c)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -187,6 +205,39 @@ func (b *BackupConfiguration) setDefaultRetentionPolicy(ctx context.Context, c c
 	}
 }
 
+// getSessionNames returns the names of the configured sessions of a BackupConfiguration or BackupBatch
+func getSessionNames[S v1alpha1.Session | v1alpha1.BatchSession](sessions []S) []string {
+	var names []string
+	for _, session := range sessions {
+		var config *v1alpha1.SessionConfig
+		switch s := any(session).(type) {
+		case v1alpha1.Session:
+			config = s.SessionConfig
+		case v1alpha1.BatchSession:
+			config = s.SessionConfig
+		}
+		if config != nil {
+			names = append(names, config.Name)
+		}
+	}
+	return names
+}
+
+func validatePauseInfo(info *apis.PauseInfo, sessions []string) error {
+	if info == nil {
+		return nil
+	}
+	for _, session := range info.Sessions {
+		if !slices.Contains(sessions, session) {
+			return fmt.Errorf("paused session %q does not exist", session)
+		}
+	}
+	if info.PausedAt != nil && info.ResumeAt != nil && !info.ResumeAt.After(info.PausedAt.Time) {
+		return fmt.Errorf("resume time must be after the pause time")
+	}
+	return nil
+}
+
 func (b *BackupConfiguration) getDefaultStorage(ctx context.Context, c client.Client) *storageapi.BackupStorage {
 	bsList := &storageapi.BackupStorageList{}
 	if err := c.List(ctx, bsList); err != nil {
@@ -372,6 +423,186 @@ func (b *BackupConfiguration) validateSessionConfig(session v1alpha1.Session) er
 		return fmt.Errorf("scheduler is empty for session: %q. Please provide scheduler", session.Name)
 	}
 
+	if err := validateScheduler(session.Name, session.Scheduler); err != nil {
+		return err
+	}
+
+	if err := validateBackupTypePolicy(session); err != nil {
+		return err
+	}
+
+	if err := validateSuccessPolicy(session.Name, session.SuccessPolicy, session.Repositories); err != nil {
+		return err
+	}
+
+	if session.RPO != nil && session.RPO.Duration <= 0 {
+		return fmt.Errorf("rpo for session: %q must be a positive duration", session.Name)
+	}
+
+	if session.Hooks != nil {
+		if err := validateUndoHooks(session.Hooks.PreBackup, session.Hooks.PostBackup); err != nil {
+			return fmt.Errorf("invalid hooks for session: %q. Reason: %w", session.Name, err)
+		}
+		if err := v1alpha1.ValidateHookNames(session.Hooks.PreBackup, session.Hooks.PostBackup); err != nil {
+			return fmt.Errorf("invalid hooks for session: %q. Reason: %w", session.Name, err)
+		}
+	}
+
+	return b.validateTriggers(session)
+}
+
+func validateUndoHooks(preHooks, postHooks []v1alpha1.HookInfo) error {
+	for _, hook := range postHooks {
+		if hook.UndoHook != "" {
+			return fmt.Errorf("undo hook can not be specified for post hook %q", hook.Name)
+		}
+	}
+	for _, hook := range preHooks {
+		if hook.UndoHook == "" {
+			continue
+		}
+		if !slices.ContainsFunc(postHooks, func(h v1alpha1.HookInfo) bool { return h.Name == hook.UndoHook }) {
+			return fmt.Errorf("undo hook %q of pre hook %q is not found in the post hooks", hook.UndoHook, hook.Name)
+		}
+	}
+	return nil
+}
+
+func validateSuccessPolicy(sessionName string, policy *v1alpha1.BackupSuccessPolicy, repositories []v1alpha1.RepositoryInfo) error {
+	if policy == nil {
+		return nil
+	}
+
+	repos := make(map[string]struct{})
+	for _, repo := range repositories {
+		repos[repo.Name] = struct{}{}
+	}
+	for _, repo := range policy.RequiredRepositories {
+		if _, ok := repos[repo]; !ok {
+			return fmt.Errorf("required repository %q of success policy is not a repository of session: %q", repo, sessionName)
+		}
+	}
+
+	if policy.Type == v1alpha1.BackupSuccessPolicyQuorum {
+		if policy.Quorum == nil || *policy.Quorum < 1 {
+			return fmt.Errorf("quorum must be specified for %q success policy of session: %q", policy.Type, sessionName)
+		}
+		if int(*policy.Quorum) > len(repositories) {
+			return fmt.Errorf("quorum %d of success policy exceeds the number of repositories of session: %q", *policy.Quorum, sessionName)
+		}
+	}
+	return nil
+}
+
+func validateBackupTypePolicy(session v1alpha1.Session) error {
+	policy := session.BackupTypePolicy
+	if policy == nil {
+		return nil
+	}
+	if policy.FullBackupSchedule == "" && policy.MaxIncrementals == nil {
+		return fmt.Errorf("backup type policy for session: %q must specify either fullBackupSchedule or maxIncrementals", session.Name)
+	}
+	if policy.FullBackupSchedule != "" {
+		if _, err := policy.ParseFullBackupSchedule(); err != nil {
+			return fmt.Errorf("invalid full backup schedule %q for session: %q. Reason: %w", policy.FullBackupSchedule, session.Name, err)
+		}
+	}
+	if policy.MaxIncrementals != nil && *policy.MaxIncrementals < 0 {
+		return fmt.Errorf("maxIncrementals for session: %q can not be negative", session.Name)
+	}
+	return nil
+}
+
+func (b *BackupConfiguration) validateTriggers(session v1alpha1.Session) error {
+	triggers := make(map[string]struct{})
+	for _, trigger := range session.Triggers {
+		if trigger.Name == "" {
+			return fmt.Errorf("trigger name is empty for session: %q", session.Name)
+		}
+		if _, ok := triggers[trigger.Name]; ok {
+			return fmt.Errorf("duplicate trigger name found: %q for session: %q", trigger.Name, session.Name)
+		}
+		triggers[trigger.Name] = struct{}{}
+
+		switch trigger.Type {
+		case v1alpha1.BackupTriggerObjectChange:
+			if trigger.ObjectChange == nil || len(trigger.ObjectChange.ConfigMaps)+len(trigger.ObjectChange.Secrets) == 0 {
+				return fmt.Errorf("no ConfigMap or Secret to watch for trigger %q of session: %q", trigger.Name, session.Name)
+			}
+		case v1alpha1.BackupTriggerWebhook:
+			if trigger.Webhook == nil || trigger.Webhook.SecretRef == nil || trigger.Webhook.SecretRef.Name == "" {
+				return fmt.Errorf("token secret is not specified for webhook trigger %q of session: %q", trigger.Name, session.Name)
+			}
+		case v1alpha1.BackupTriggerBackupSessionSucceeded:
+			chain := trigger.BackupSessionSucceeded
+			if chain == nil || chain.Session == "" {
+				return fmt.Errorf("session to follow is not specified for trigger %q of session: %q", trigger.Name, session.Name)
+			}
+			if chain.Session == session.Name && (chain.Invoker == nil ||
+				(chain.Invoker.Kind == v1alpha1.ResourceKindBackupConfiguration && chain.Invoker.Name == b.Name)) {
+				return fmt.Errorf("trigger %q of session: %q can not follow the session itself", trigger.Name, session.Name)
+			}
+		case v1alpha1.BackupTriggerDatabaseUpgrade, v1alpha1.BackupTriggerVolumeResize:
+		default:
+			return fmt.Errorf("invalid type %q for trigger %q of session: %q", trigger.Type, trigger.Name, session.Name)
+		}
+
+		if trigger.RateLimit != nil && (trigger.RateLimit.MaxBackups < 1 || trigger.RateLimit.Period.Duration <= 0) {
+			return fmt.Errorf("rate limit of trigger %q for session: %q must have positive maxBackups and period", trigger.Name, session.Name)
+		}
+	}
+	return nil
+}
+
+func validateScheduler(sessionName string, scheduler *v1alpha1.SchedulerSpec) error {
+	// the "H" fields resolve to valid values for any UID, so an empty one is enough for validation
+	if _, err := scheduler.ParseSchedule(""); err != nil {
+		return fmt.Errorf("invalid schedule %q for session: %q. Reason: %w", scheduler.Schedule, sessionName, err)
+	}
+	if scheduler.Jitter != nil && scheduler.Jitter.Duration < 0 {
+		return fmt.Errorf("jitter can not be negative for session: %q", sessionName)
+	}
+	if _, err := scheduler.GetLocation(); err != nil {
+		return fmt.Errorf("invalid time zone for session: %q. Reason: %w", sessionName, err)
+	}
+
+	for _, window := range scheduler.Windows {
+		if err := validateTimeWindow(window); err != nil {
+			return fmt.Errorf("invalid backup window for session: %q. Reason: %w", sessionName, err)
+		}
+	}
+
+	for _, blackout := range scheduler.Blackouts {
+		if blackout.Name == "" {
+			return fmt.Errorf("blackout period name is empty for session: %q", sessionName)
+		}
+		if blackout.Start == nil && blackout.End == nil && blackout.Recurring == nil {
+			return fmt.Errorf("blackout period %q for session: %q must specify either start/end or a recurring window", blackout.Name, sessionName)
+		}
+		if blackout.Start != nil && blackout.End != nil && !blackout.End.After(blackout.Start.Time) {
+			return fmt.Errorf("end of blackout period %q for session: %q must be after its start", blackout.Name, sessionName)
+		}
+		if blackout.Recurring != nil {
+			if err := validateTimeWindow(*blackout.Recurring); err != nil {
+				return fmt.Errorf("invalid recurring window of blackout period %q for session: %q. Reason: %w", blackout.Name, sessionName, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateTimeWindow(window v1alpha1.TimeWindow) error {
+	if _, err := v1alpha1.ParseClockTime(window.StartTime); err != nil {
+		return err
+	}
+	if _, err := v1alpha1.ParseClockTime(window.EndTime); err != nil {
+		return err
+	}
+	for _, d := range window.DaysOfMonth {
+		if d == 0 || d > 31 || d < -31 {
+			return fmt.Errorf("invalid day of month %d", d)
+		}
+	}
 	return nil
 }
 
@@ -675,3 +906,15 @@ func (b *BackupConfiguration) getHookTemplatesFromHookInfo(hooks []v1alpha1.Hook
 	}
 	return hookTemplates
 }
+
+func (b *BackupConfiguration) validateHookParams(ctx context.Context, c client.Client) error {
+	for _, session := range b.Spec.Sessions {
+		if session.Hooks == nil {
+			continue
+		}
+		if err := validateHookParams(ctx, c, slices.Concat(session.Hooks.PreBackup, session.Hooks.PostBackup)); err != nil {
+			return fmt.Errorf("invalid hooks for session: %q. Reason: %w", session.Name, err)
+		}
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"kubestash.dev/apimachinery/apis/core/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -115,8 +116,12 @@ func (b *BackupVerifier) validateVerifier() error {
 		return fmt.Errorf("addonInfo in restoreOption for backupVerifier %s/%s cannot be empty", b.Namespace, b.Name)
 	}
 
-	if b.Spec.Scheduler != nil {
-		return fmt.Errorf("scheduler for backupVerifier %s/%s cannot be empty", b.Namespace, b.Name)
+	if err := b.validateStrategy(); err != nil {
+		return err
+	}
+
+	if err := b.validateSandbox(); err != nil {
+		return err
 	}
 
 	if b.Spec.Type == "" {
@@ -130,6 +135,9 @@ func (b *BackupVerifier) validateVerifier() error {
 		if b.Spec.Function == "" {
 			return fmt.Errorf("function in backupVerifier %s/%s cannot be empty", b.Namespace, b.Name)
 		}
+		if err := b.validateQueries(); err != nil {
+			return err
+		}
 	}
 
 	if b.Spec.Type == v1alpha1.ScriptVerificationType {
@@ -148,3 +156,79 @@ func (b *BackupVerifier) validateVerifier() error {
 
 	return nil
 }
+
+func (b *BackupVerifier) validateStrategy() error {
+	strategy := b.GetStrategyType()
+	if strategy == v1alpha1.VerifyScheduled && b.Spec.Scheduler == nil {
+		return fmt.Errorf("scheduler for backupVerifier %s/%s cannot be empty for %q strategy", b.Namespace, b.Name, strategy)
+	}
+	if strategy != v1alpha1.VerifyScheduled && b.Spec.Scheduler != nil {
+		return fmt.Errorf("scheduler for backupVerifier %s/%s can be specified only for %q strategy", b.Namespace, b.Name, v1alpha1.VerifyScheduled)
+	}
+	if b.Spec.Scheduler != nil {
+		// the effective schedule is not resolved for a BackupVerifier, so its CronJob would fail to parse an "H" field
+		if b.Spec.Scheduler.HasHashedFields() {
+			return fmt.Errorf("invalid schedule %q for backupVerifier %s/%s. Reason: \"H\" fields are not supported", b.Spec.Scheduler.Schedule, b.Namespace, b.Name)
+		}
+		if _, err := b.Spec.Scheduler.ParseSchedule(""); err != nil {
+			return fmt.Errorf("invalid schedule %q for backupVerifier %s/%s. Reason: %w", b.Spec.Scheduler.Schedule, b.Namespace, b.Name, err)
+		}
+	}
+
+	if b.Spec.Strategy == nil {
+		return nil
+	}
+	if strategy == v1alpha1.VerifyEveryNSnapshots && (b.Spec.Strategy.SnapshotInterval == nil || *b.Spec.Strategy.SnapshotInterval < 1) {
+		return fmt.Errorf("snapshotInterval for backupVerifier %s/%s must be at least 1 for %q strategy", b.Namespace, b.Name, strategy)
+	}
+	if strategy != v1alpha1.VerifyEveryNSnapshots && b.Spec.Strategy.SnapshotInterval != nil {
+		return fmt.Errorf("snapshotInterval for backupVerifier %s/%s can be specified only for %q strategy", b.Namespace, b.Name, v1alpha1.VerifyEveryNSnapshots)
+	}
+	if sampling := b.Spec.Strategy.Sampling; sampling != nil {
+		if sampling.Count < 1 {
+			return fmt.Errorf("sampling count for backupVerifier %s/%s must be at least 1", b.Namespace, b.Name)
+		}
+		if sampling.MaxAge != nil && sampling.MaxAge.Duration <= 0 {
+			return fmt.Errorf("sampling maxAge for backupVerifier %s/%s must be a positive duration", b.Namespace, b.Name)
+		}
+	}
+	return nil
+}
+
+func (b *BackupVerifier) validateQueries() error {
+	if b.Spec.RestoreOption.Target == nil {
+		return fmt.Errorf("target in restoreOption for backupVerifier %s/%s cannot be empty for query type verifier", b.Namespace, b.Name)
+	}
+
+	queries, err := v1alpha1.DecodeQueries(b.Spec.RestoreOption.Target.Kind, b.Spec.Query)
+	if err != nil {
+		return fmt.Errorf("invalid query in backupVerifier %s/%s. Reason: %w", b.Namespace, b.Name, err)
+	}
+	if err := v1alpha1.ValidateQueries(queries); err != nil {
+		return fmt.Errorf("invalid query in backupVerifier %s/%s. Reason: %w", b.Namespace, b.Name, err)
+	}
+	return nil
+}
+
+func (b *BackupVerifier) validateSandbox() error {
+	sandbox := b.Spec.RestoreOption.Sandbox
+	if sandbox == nil {
+		return nil
+	}
+	if b.Spec.RestoreOption.Target == nil {
+		return fmt.Errorf("target in restoreOption for backupVerifier %s/%s cannot be empty when sandbox is used", b.Namespace, b.Name)
+	}
+	if sandbox.NamespacePrefix != "" {
+		// the prefix is followed by a hyphen and an 8 characters long suffix
+		if errs := validation.IsDNS1123Label(sandbox.NamespacePrefix); len(errs) > 0 || len(sandbox.NamespacePrefix) > validation.DNS1123LabelMaxLength-9 {
+			return fmt.Errorf("invalid sandbox namespacePrefix %q for backupVerifier %s/%s", sandbox.NamespacePrefix, b.Namespace, b.Name)
+		}
+	}
+	if sandbox.TTL != nil && sandbox.TTL.Duration <= 0 {
+		return fmt.Errorf("sandbox ttl for backupVerifier %s/%s must be a positive duration", b.Namespace, b.Name)
+	}
+	if sandbox.Replicas != nil && *sandbox.Replicas < 1 {
+		return fmt.Errorf("sandbox replicas for backupVerifier %s/%s must be at least 1", b.Namespace, b.Name)
+	}
+	return nil
+}
@@ -18,14 +18,17 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/apis/core/v1alpha1"
 
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -97,6 +100,14 @@ func (*HookTemplateCustomWebhook) ValidateCreate(ctx context.Context, obj runtim
 		return nil, err
 	}
 
+	if err := h.validateOutputs(); err != nil {
+		return nil, err
+	}
+
+	if err := h.validateParams(); err != nil {
+		return nil, err
+	}
+
 	return nil, h.validateExecutorInfo()
 }
 
@@ -127,6 +138,14 @@ func (*HookTemplateCustomWebhook) ValidateUpdate(ctx context.Context, oldObj, ne
 		return nil, err
 	}
 
+	if err := hNew.validateOutputs(); err != nil {
+		return nil, err
+	}
+
+	if err := hNew.validateParams(); err != nil {
+		return nil, err
+	}
+
 	return nil, hNew.validateExecutorInfo()
 }
 
@@ -164,28 +183,113 @@ func (r *HookTemplate) validateExecutorInfo() error {
 		if r.Spec.Executor.Pod == nil {
 			return fmt.Errorf("pod field can not be empty for pod type executor")
 		}
-		if r.Spec.Executor.Pod.Selector == "" {
-			return fmt.Errorf("selector field can not be empty for pod type executor")
+		return validatePodExecutor(r.Spec.Executor.Pod)
+	}
+
+	if r.Spec.Executor.Type == v1alpha1.HookExecutorHTTP {
+		if r.Spec.Executor.HTTP == nil {
+			return fmt.Errorf("http field can not be empty for http type executor")
 		}
+		return validateHTTPExecutor(r.Spec.Executor.HTTP)
+	}
+	return nil
+}
 
-		selectors := strings.SplitSeq(r.Spec.Executor.Pod.Selector, ",")
-		for sel := range selectors {
-			if len(strings.Split(strings.Trim(sel, " "), "=")) < 2 {
-				return fmt.Errorf("invalid selector is provided for pod type executor")
-			}
+func validatePodExecutor(pod *v1alpha1.PodHookExecutorSpec) error {
+	if _, err := pod.GetSelector(); err != nil {
+		return err
+	}
+	if pod.Strategy == v1alpha1.ExecuteOnN && (pod.Count == nil || *pod.Count < 1) {
+		return fmt.Errorf("count must be at least 1 for %q strategy", v1alpha1.ExecuteOnN)
+	}
+	if pod.Strategy != v1alpha1.ExecuteOnN && pod.Count != nil {
+		return fmt.Errorf("count can be specified only for %q strategy", v1alpha1.ExecuteOnN)
+	}
+	if pod.Parallelism != nil && *pod.Parallelism < 1 {
+		return fmt.Errorf("parallelism must be at least 1 for pod type executor")
+	}
+	if pod.Role != nil && pod.Strategy != v1alpha1.ExecuteOnPrimary && pod.Strategy != v1alpha1.ExecuteOnLeader {
+		return fmt.Errorf("role can be specified only for %q and %q strategies", v1alpha1.ExecuteOnPrimary, v1alpha1.ExecuteOnLeader)
+	}
+	return nil
+}
+
+func validateHTTPExecutor(spec *v1alpha1.HTTPHookExecutorSpec) error {
+	if spec.URL == "" {
+		return fmt.Errorf("url can not be empty for http type executor")
+	}
+	for _, code := range spec.ExpectedStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid expected status code %d for http type executor", code)
+		}
+	}
+	for _, header := range spec.Headers {
+		if header.Name == "" {
+			return fmt.Errorf("header name can not be empty for http type executor")
+		}
+		if (header.Value == "") == (header.SecretKeyRef == nil) {
+			return fmt.Errorf("exactly one of value or secretKeyRef must be specified for header %q", header.Name)
 		}
 	}
+	if spec.MaxRetry < 0 {
+		return fmt.Errorf("maxRetry can not be negative for http type executor")
+	}
+	if spec.RetryInterval != nil && spec.RetryInterval.Duration <= 0 {
+		return fmt.Errorf("retryInterval must be a positive duration for http type executor")
+	}
+	if spec.Timeout != nil && spec.Timeout.Duration <= 0 {
+		return fmt.Errorf("timeout must be a positive duration for http type executor")
+	}
+	return nil
+}
+
+func (r *HookTemplate) validateParams() error {
+	names := make(map[string]bool)
+	for _, param := range r.Spec.Params {
+		if err := param.Validate(); err != nil {
+			return err
+		}
+		if names[param.Name] {
+			return fmt.Errorf("duplicate parameter name found: %q", param.Name)
+		}
+		names[param.Name] = true
+	}
 	return nil
 }
 
 func (r *HookTemplate) validateActionForNonFunctionExecutor() error {
 	if r.Spec.Executor.Type != v1alpha1.HookExecutorFunction &&
+		r.Spec.Executor.Type != v1alpha1.HookExecutorHTTP &&
 		r.Spec.Action == nil {
 		return fmt.Errorf("action can not be empty for pod or operator type executor")
 	}
 	return nil
 }
 
+func (r *HookTemplate) validateOutputs() error {
+	names := make(map[string]string)
+	for _, output := range r.Spec.Outputs {
+		if output.Name == "" {
+			return fmt.Errorf("output name can not be empty")
+		}
+		if strings.ContainsAny(output.Name, "= \t") {
+			return fmt.Errorf("output name %q can not contain '=' or whitespace", output.Name)
+		}
+		name := v1alpha1.ToVariableName(output.Name)
+		if name == "" {
+			return fmt.Errorf("output name %q must contain at least one letter or digit", output.Name)
+		}
+		if other, ok := names[name]; ok {
+			if other == output.Name {
+				return fmt.Errorf("duplicate output %q", output.Name)
+			}
+			return fmt.Errorf("outputs %q and %q conflict with each other in the output variable names", other, output.Name)
+		}
+		names[name] = output.Name
+	}
+	return nil
+}
+
 func (r *HookTemplate) validateUsagePolicy() error {
 	if *r.Spec.UsagePolicy.AllowedNamespaces.From == apis.NamespacesFromSelector &&
 		r.Spec.UsagePolicy.AllowedNamespaces.Selector == nil {
@@ -193,3 +297,30 @@ func (r *HookTemplate) validateUsagePolicy() error {
 	}
 	return nil
 }
+
+// validateHookParams validates the parameters of the hooks against the parameter definitions of the respective HookTemplates
+func validateHookParams(ctx context.Context, c client.Client, hooks []v1alpha1.HookInfo) error {
+	for _, hook := range hooks {
+		if hook.HookTemplate == nil {
+			continue
+		}
+		ht := &v1alpha1.HookTemplate{}
+		if err := c.Get(ctx, client.ObjectKey{Name: hook.HookTemplate.Name, Namespace: hook.HookTemplate.Namespace}, ht); err != nil {
+			if kerr.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		params := make(map[string]any)
+		if hook.Params != nil && len(hook.Params.Raw) > 0 {
+			if err := json.Unmarshal(hook.Params.Raw, &params); err != nil {
+				return fmt.Errorf("invalid params for hook: %q. Reason: %w", hook.Name, err)
+			}
+		}
+		if err := apis.ValidateParameters(ht.Spec.Params, params); err != nil {
+			return fmt.Errorf("invalid params for hook: %q. Reason: %w", hook.Name, err)
+		}
+	}
+	return nil
+}
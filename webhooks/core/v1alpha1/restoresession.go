@@ -19,6 +19,9 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"path"
+	"slices"
+	"strings"
 
 	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/apis/core/v1alpha1"
@@ -72,7 +75,24 @@ func (*RestoreSessionCustomWebhook) ValidateCreate(ctx context.Context, obj runt
 		return nil, err
 	}
 
-	return nil, r.validateHookTemplatesAgainstUsagePolicy(context.Background(), apis.GetRuntimeClient())
+	if err := r.validatePathFilters(); err != nil {
+		return nil, err
+	}
+
+	if r.Spec.Hooks != nil {
+		if err := validateUndoHooks(r.Spec.Hooks.PreRestore, r.Spec.Hooks.PostRestore); err != nil {
+			return nil, err
+		}
+		if err := v1alpha1.ValidateHookNames(r.Spec.Hooks.PreRestore, r.Spec.Hooks.PostRestore); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.validateHookTemplatesAgainstUsagePolicy(context.Background(), apis.GetRuntimeClient()); err != nil {
+		return nil, err
+	}
+
+	return nil, r.validateHookParams(context.Background(), apis.GetRuntimeClient())
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -94,7 +114,28 @@ func (*RestoreSessionCustomWebhook) ValidateUpdate(ctx context.Context, oldObj,
 		return nil, err
 	}
 
-	return nil, rNew.validateHookTemplatesAgainstUsagePolicy(context.Background(), apis.GetRuntimeClient())
+	if err := rNew.validatePathFilters(); err != nil {
+		return nil, err
+	}
+
+	if rNew.Spec.DryRun != rOld.Spec.DryRun {
+		return nil, fmt.Errorf("dryRun can not be changed once the RestoreSession has been created")
+	}
+
+	if rNew.Spec.Hooks != nil {
+		if err := validateUndoHooks(rNew.Spec.Hooks.PreRestore, rNew.Spec.Hooks.PostRestore); err != nil {
+			return nil, err
+		}
+		if err := v1alpha1.ValidateHookNames(rNew.Spec.Hooks.PreRestore, rNew.Spec.Hooks.PostRestore); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rNew.validateHookTemplatesAgainstUsagePolicy(context.Background(), apis.GetRuntimeClient()); err != nil {
+		return nil, err
+	}
+
+	return nil, rNew.validateHookParams(context.Background(), apis.GetRuntimeClient())
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -119,6 +160,10 @@ func (r *RestoreSession) ValidateDataSource() error {
 		if err := r.checkIfRepoIsEmptyForTargetTime(); err != nil {
 			return err
 		}
+	} else if r.Spec.DataSource.SnapshotSelector != nil {
+		if err := r.validateSnapshotSelector(); err != nil {
+			return err
+		}
 	} else {
 		if err := r.checkIfSnapshotIsEmpty(); err != nil {
 			return err
@@ -152,13 +197,65 @@ func (r *RestoreSession) checkIfSnapshotIsEmpty() error {
 }
 
 func (r *RestoreSession) checkIfRepoIsEmptyForLatestSnapshot() error {
-	if r.Spec.DataSource.Snapshot == "latest" &&
+	if r.Spec.DataSource.GetSnapshotSelector() != nil &&
 		r.Spec.DataSource.Repository == "" {
-		return fmt.Errorf("repository can not be empty for latest snapshot")
+		return fmt.Errorf("repository can not be empty for %s snapshot", r.Spec.DataSource.Snapshot)
 	}
 	return nil
 }
 
+func (r *RestoreSession) validateSnapshotSelector() error {
+	if r.Spec.DataSource.Snapshot != "" {
+		return fmt.Errorf("snapshot and snapshotSelector can not be specified together")
+	}
+	if r.Spec.DataSource.Repository == "" {
+		return fmt.Errorf("repository can not be empty for snapshotSelector")
+	}
+	if ls := r.Spec.DataSource.SnapshotSelector.LabelSelector; ls != nil {
+		if _, err := metav1.LabelSelectorAsSelector(ls); err != nil {
+			return fmt.Errorf("invalid labelSelector in snapshotSelector: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *RestoreSession) validatePathFilters() error {
+	components := map[string]bool{}
+	for _, f := range r.Spec.PathFilters {
+		if components[f.Component] {
+			return fmt.Errorf("multiple path filters specified for component %q", f.Component)
+		}
+		components[f.Component] = true
+
+		if f.Component != "" && len(r.Spec.DataSource.Components) > 0 && !slices.Contains(r.Spec.DataSource.Components, f.Component) {
+			return fmt.Errorf("path filter component %q is not selected in dataSource components", f.Component)
+		}
+		if len(f.Include) == 0 && len(f.Exclude) == 0 && f.TargetPath == "" {
+			return fmt.Errorf("path filter for component %q must specify include, exclude or targetPath", f.Component)
+		}
+		for _, pattern := range slices.Concat(f.Include, f.Exclude) {
+			if err := validatePathPattern(pattern); err != nil {
+				return fmt.Errorf("invalid path pattern %q for component %q: %w", pattern, f.Component, err)
+			}
+		}
+		if f.TargetPath != "" && (!path.IsAbs(f.TargetPath) || path.Clean(f.TargetPath) != f.TargetPath) {
+			return fmt.Errorf("targetPath %q for component %q must be an absolute and clean path", f.TargetPath, f.Component)
+		}
+	}
+	return nil
+}
+
+func validatePathPattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("pattern can not be empty")
+	}
+	if slices.Contains(strings.Split(pattern, "/"), "..") {
+		return fmt.Errorf("pattern can not refer to the parent directory")
+	}
+	_, err := path.Match(pattern, "")
+	return err
+}
+
 func (r *RestoreSession) validateHookTemplatesAgainstUsagePolicy(ctx context.Context, c client.Client) error {
 	hookTemplates := r.getHookTemplates()
 	for _, ht := range hookTemplates {
@@ -205,3 +302,10 @@ func (r *RestoreSession) getHookTemplatesFromHookInfo(hooks []v1alpha1.HookInfo)
 	}
 	return hookTemplates
 }
+
+func (r *RestoreSession) validateHookParams(ctx context.Context, c client.Client) error {
+	if r.Spec.Hooks == nil {
+		return nil
+	}
+	return validateHookParams(ctx, c, slices.Concat(r.Spec.Hooks.PreRestore, r.Spec.Hooks.PostRestore))
+}
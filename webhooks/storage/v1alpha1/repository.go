@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 
+	"kubestash.dev/apimachinery/apis"
 	"kubestash.dev/apimachinery/apis/storage/v1alpha1"
 
+	"github.com/robfig/cron/v3"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -60,7 +62,7 @@ func (*RepositoryCustomWebhook) Default(ctx context.Context, obj runtime.Object)
 	}
 	repositorylog.Info("default", "name", r.Name)
 
-	// TODO(user): fill in your default logic.
+	r.Spec.PauseInfo = apis.DefaultPauseInfo(ctx, r.Spec.Paused, r.Spec.PauseInfo)
 	return nil
 }
 
@@ -79,8 +81,7 @@ func (*RepositoryCustomWebhook) ValidateCreate(ctx context.Context, obj runtime.
 	}
 	repositorylog.Info("Validation for Repository upon creation", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object creation.
-	return nil, nil
+	return nil, r.validateIntegrityCheck()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -102,7 +103,7 @@ func (*RepositoryCustomWebhook) ValidateUpdate(ctx context.Context, oldObj, newO
 		return nil, fmt.Errorf("repository path can not be updated")
 	}
 
-	return nil, nil
+	return nil, rNew.validateIntegrityCheck()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -118,3 +119,28 @@ func (*RepositoryCustomWebhook) ValidateDelete(ctx context.Context, obj runtime.
 	// TODO(user): fill in your validation logic upon object deletion.
 	return nil, nil
 }
+
+func (r *Repository) validateIntegrityCheck() error {
+	policy := r.Spec.IntegrityCheck
+	if policy == nil {
+		return nil
+	}
+	if policy.Schedule == "" {
+		return fmt.Errorf("schedule for integrity check of repository %s/%s cannot be empty", r.Namespace, r.Name)
+	}
+	if _, err := cron.ParseStandard(policy.Schedule); err != nil {
+		return fmt.Errorf("invalid schedule %q for integrity check of repository %s/%s. Reason: %w", policy.Schedule, r.Namespace, r.Name, err)
+	}
+	if policy.ReadDataPercentage != nil {
+		if policy.GetMode() != v1alpha1.IntegrityCheckModeReadData {
+			return fmt.Errorf("readDataPercentage can only be specified with %q integrity check mode", v1alpha1.IntegrityCheckModeReadData)
+		}
+		if *policy.ReadDataPercentage < 1 || *policy.ReadDataPercentage > 100 {
+			return fmt.Errorf("readDataPercentage must be between 1 and 100")
+		}
+	}
+	if policy.HistoryLimit != nil && *policy.HistoryLimit < 1 {
+		return fmt.Errorf("historyLimit for integrity check must be at least 1")
+	}
+	return nil
+}
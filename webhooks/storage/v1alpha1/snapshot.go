@@ -0,0 +1,64 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"kubestash.dev/apimachinery/apis"
+	"kubestash.dev/apimachinery/apis/storage/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var snapshotlog = logf.Log.WithName("snapshot-resource")
+
+type SnapshotCustomWebhook struct{}
+
+type Snapshot struct {
+	*v1alpha1.Snapshot
+}
+
+// SetupSnapshotWebhookWithManager registers the webhook for Snapshot in the manager.
+func SetupSnapshotWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Snapshot{}).
+		WithDefaulter(&SnapshotCustomWebhook{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-storage-kubestash-com-v1alpha1-snapshot,mutating=true,failurePolicy=fail,sideEffects=None,groups=storage.kubestash.com,resources=snapshots,verbs=create;update,versions=v1alpha1,name=msnapshot.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomDefaulter = &SnapshotCustomWebhook{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (*SnapshotCustomWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	var ok bool
+	var s Snapshot
+	s.Snapshot, ok = obj.(*v1alpha1.Snapshot)
+	if !ok {
+		return fmt.Errorf("expected Snapshot but got %T", obj)
+	}
+	snapshotlog.Info("default", "name", s.Name)
+
+	s.Spec.PauseInfo = apis.DefaultPauseInfo(ctx, s.Spec.Paused, s.Spec.PauseInfo)
+	return nil
+}